@@ -54,9 +54,12 @@ type GardenerClusterSpec struct {
 	Shoot      Shoot      `json:"shoot"`
 }
 
-// Shoot defines the name of the Shoot resource
+// Shoot defines the name and the namespace of the Shoot resource
 type Shoot struct {
 	Name string `json:"name"`
+	// Namespace of the Gardener project of the Shoot, the default project namespace is used if empty
+	// +optional
+	Namespace string `json:"namespace,omitempty"`
 }
 
 // Kubeconfig defines the desired kubeconfig location
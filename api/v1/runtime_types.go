@@ -34,6 +34,23 @@ const (
 	Finalizer                              = "runtime-controller.infrastructure-manager.kyma-project.io/deletion-hook"
 	AnnotationGardenerCloudDelConfirmation = "confirmation.gardener.cloud/deletion"
 	LabelControlledByProvisioner           = "kyma-project.io/controlled-by-provisioner"
+
+	// AnnotationRetry forces the reprocessing of the Runtime, it is removed once processed.
+	// The AnnotationRetryValueShoot value additionally requests Gardener to retry the failed shoot operation
+	AnnotationRetry           = "operator.kyma-project.io/retry"
+	AnnotationRetryValueShoot = "shoot"
+
+	// AnnotationDeletionProtection set to "true" prevents the deletion of the shoot when the Runtime is deleted.
+	// Setting it during the deletion grace period cancels the deletion, removing it resumes the deletion
+	AnnotationDeletionProtection = "operator.kyma-project.io/deletion-protection"
+
+	// AnnotationAdopt set to "true" on the created Runtime takes over the existing shoot instead of creating a new one.
+	// The shoot is adopted only if the conversion of the Runtime reproduces it
+	AnnotationAdopt = "operator.kyma-project.io/adopt"
+
+	// AnnotationPlan set to "true" holds back the changes of the Runtime, the shoot patch is only planned with a dry-run.
+	// The planned changes are reported in the status, removing the annotation applies them
+	AnnotationPlan = "operator.kyma-project.io/plan"
 )
 
 const (
@@ -56,6 +73,7 @@ const (
 	RuntimeStateFailed      = "Failed"
 	RuntimeStatePending     = "Pending"
 	RuntimeStateTerminating = "Terminating"
+	RuntimeStateHibernated  = "Hibernated"
 )
 
 type RuntimeConditionType string
@@ -68,6 +86,11 @@ const (
 	ConditionTypeRuntimeConfigured        RuntimeConditionType = "Configured"
 	ConditionTypeAuditLogConfigured       RuntimeConditionType = "AuditlogConfigured"
 	ConditionTypeRuntimeDeprovisioned     RuntimeConditionType = "Deprovisioned"
+	ConditionTypeRuntimeHibernated        RuntimeConditionType = "Hibernated"
+	ConditionTypeKubernetesUpgraded       RuntimeConditionType = "KubernetesUpgraded"
+	ConditionTypeDeletionScheduled        RuntimeConditionType = "DeletionScheduled"
+	ConditionTypeDrifted                  RuntimeConditionType = "Drifted"
+	ConditionTypePlanned                  RuntimeConditionType = "Planned"
 )
 
 type RuntimeConditionReason string
@@ -96,6 +119,16 @@ const (
 	ConditionReasonKubernetesAPIErr     = RuntimeConditionReason("KubernetesErr")
 	ConditionReasonSerializationError   = RuntimeConditionReason("SerializationErr")
 	ConditionReasonDeleted              = RuntimeConditionReason("Deleted")
+	ConditionReasonDeletionScheduled    = RuntimeConditionReason("DeletionScheduled")
+	ConditionReasonDeletionProtected    = RuntimeConditionReason("DeletionProtected")
+	ConditionReasonShootAdopted         = RuntimeConditionReason("ShootAdopted")
+	ConditionReasonAdoptionErr          = RuntimeConditionReason("AdoptionErr")
+	ConditionReasonNoDrift              = RuntimeConditionReason("NoDrift")
+	ConditionReasonDriftDetected        = RuntimeConditionReason("DriftDetected")
+	ConditionReasonDriftCorrected       = RuntimeConditionReason("DriftCorrected")
+	ConditionReasonPlanCompleted        = RuntimeConditionReason("PlanCompleted")
+	ConditionReasonPlanErr              = RuntimeConditionReason("PlanErr")
+	ConditionReasonPlanRejected         = RuntimeConditionReason("PlanRejected")
 
 	ConditionReasonAdministratorsConfigured     = RuntimeConditionReason("AdministratorsConfigured")
 	ConditionReasonAuditLogConfigured           = RuntimeConditionReason("AuditLogConfigured")
@@ -103,6 +136,13 @@ const (
 	ConditionReasonAuditLogMissingRegionMapping = RuntimeConditionReason("AuditLogMissingRegionMappingErr")
 	ConditionReasonOidcConfigured               = RuntimeConditionReason("OidcConfigured")
 	ConditionReasonOidcError                    = RuntimeConditionReason("OidcConfigurationErr")
+
+	ConditionReasonHibernated = RuntimeConditionReason("Hibernated")
+
+	ConditionReasonKubernetesUpgradeInProgress = RuntimeConditionReason("KubernetesUpgradeInProgress")
+	ConditionReasonKubernetesUpgraded          = RuntimeConditionReason("KubernetesUpgraded")
+	ConditionReasonKubernetesUpgradeRejected   = RuntimeConditionReason("KubernetesUpgradeRejected")
+	ConditionReasonKubernetesUpgradeErr        = RuntimeConditionReason("KubernetesUpgradeErr")
 )
 
 //+kubebuilder:object:root=true
@@ -134,24 +174,142 @@ type RuntimeList struct {
 type RuntimeSpec struct {
 	Shoot    RuntimeShoot `json:"shoot"`
 	Security Security     `json:"security"`
+	// Deletion defines what happens with the shoot when the Runtime is deleted
+	// +optional
+	Deletion *Deletion `json:"deletion,omitempty"`
+	// Drift defines what happens when the shoot is changed outside of the Runtime
+	// +optional
+	Drift *Drift `json:"drift,omitempty"`
+}
+
+type DeletionPolicy string
+
+const (
+	// DeletionPolicyDelete deletes the shoot together with the Runtime
+	DeletionPolicyDelete DeletionPolicy = "Delete"
+	// DeletionPolicyOrphan keeps the shoot running, only the Runtime is deleted
+	DeletionPolicyOrphan DeletionPolicy = "Orphan"
+)
+
+type Deletion struct {
+	// +kubebuilder:validation:Enum=Delete;Orphan
+	// +kubebuilder:default=Delete
+	Policy DeletionPolicy `json:"policy,omitempty"`
+	// KeepGardenerCluster keeps the GardenerCluster CR and the kubeconfig secret of the orphaned shoot
+	// +optional
+	KeepGardenerCluster bool `json:"keepGardenerCluster,omitempty"`
+}
+
+type DriftPolicy string
+
+const (
+	// DriftPolicyReport reports the differences between the Runtime and the shoot in the Drifted condition
+	DriftPolicyReport DriftPolicy = "Report"
+	// DriftPolicyCorrect additionally patches the shoot to match the Runtime
+	DriftPolicyCorrect DriftPolicy = "Correct"
+)
+
+type Drift struct {
+	// +kubebuilder:validation:Enum=Report;Correct
+	// +kubebuilder:default=Report
+	Policy DriftPolicy `json:"policy,omitempty"`
 }
 
 // RuntimeStatus defines the observed state of Runtime
 type RuntimeStatus struct {
 	// State signifies current state of Runtime
 	// +kubebuilder:validation:Required
-	// +kubebuilder:validation:Enum=Pending;Ready;Terminating;Failed
+	// +kubebuilder:validation:Enum=Pending;Ready;Terminating;Failed;Hibernated
 	State State `json:"state,omitempty"`
 
 	// List of status conditions to indicate the status of a ServiceInstance.
 	Conditions []metav1.Condition `json:"conditions,omitempty"`
+
+	// ObservedGeneration is the most recent generation of the Runtime applied to the shoot by the controller
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// Shoot contains the details of the Gardener shoot read during the last reconciliation
+	Shoot *ShootStatus `json:"shoot,omitempty"`
+
+	// Retry contains the details of the retries of the failed operation
+	Retry *RetryStatus `json:"retry,omitempty"`
+
+	// Plan contains the shoot changes planned for the Runtime marked with the plan annotation
+	Plan *PlanStatus `json:"plan,omitempty"`
+}
+
+// PlanStatus describes the changes of the shoot which are applied once the plan annotation is removed
+type PlanStatus struct {
+	// ObservedGeneration is the generation of the Runtime the plan was computed for
+	ObservedGeneration int64 `json:"observedGeneration"`
+
+	// Changes are the fields of the shoot changed by the patch
+	Changes []PlanChange `json:"changes,omitempty"`
+
+	// NodesRollout is true if the patch triggers the rolling update of the worker nodes
+	NodesRollout bool `json:"nodesRollout"`
+
+	// Rejected is the reason why the patch would not be applied, for example the Kubernetes upgrade rejected by the validation
+	Rejected string `json:"rejected,omitempty"`
+}
+
+// PlanChange is a single field of the shoot changed by the patch
+type PlanChange struct {
+	Path    string `json:"path"`
+	Current string `json:"current,omitempty"`
+	Planned string `json:"planned,omitempty"`
+}
+
+// RetryStatus describes the retries of the operation which failed with a retryable error
+type RetryStatus struct {
+	// Attempts is the number of failed attempts of the operation
+	Attempts int32 `json:"attempts"`
+
+	// NextRetryTime is the earliest time when the operation is retried, it is not set while the requested retry is in progress
+	NextRetryTime *metav1.Time `json:"nextRetryTime,omitempty"`
+
+	// Reason is the error which caused the last failed attempt
+	Reason string `json:"reason,omitempty"`
+
+	// Generation is the generation of the Runtime the retries were made for
+	Generation int64 `json:"generation,omitempty"`
+}
+
+// ShootStatus contains the details of the Gardener shoot observed by the controller
+type ShootStatus struct {
+	// AppliedRuntimeGeneration is the generation of the Runtime that was last applied to the shoot
+	AppliedRuntimeGeneration int64 `json:"appliedRuntimeGeneration,omitempty"`
+
+	SeedName          string `json:"seedName,omitempty"`
+	Domain            string `json:"domain,omitempty"`
+	KubernetesVersion string `json:"kubernetesVersion,omitempty"`
+
+	// Workers contains the machine images used by the worker pools of the shoot
+	Workers []WorkerStatus `json:"workers,omitempty"`
+
+	// LastOperation is a copy of the last operation reported by Gardener
+	LastOperation *gardener.LastOperation `json:"lastOperation,omitempty"`
+
+	// LastErrors is a copy of the last errors reported by Gardener
+	LastErrors []gardener.LastError `json:"lastErrors,omitempty"`
+}
+
+type WorkerStatus struct {
+	Name                string `json:"name"`
+	MachineImageName    string `json:"machineImageName,omitempty"`
+	MachineImageVersion string `json:"machineImageVersion,omitempty"`
 }
 
 type RuntimeShoot struct {
-	Name                string                 `json:"name"`
-	Purpose             gardener.ShootPurpose  `json:"purpose"`
-	PlatformRegion      string                 `json:"platformRegion"`
-	Region              string                 `json:"region"`
+	//+kubebuilder:validation:XValidation:rule="self == oldSelf",message="name is immutable"
+	Name           string                `json:"name"`
+	Purpose        gardener.ShootPurpose `json:"purpose"`
+	PlatformRegion string                `json:"platformRegion"`
+	//+kubebuilder:validation:XValidation:rule="self == oldSelf",message="region is immutable"
+	Region string `json:"region"`
+	// ProjectName selects the Gardener project of the shoot, the project from the converter configuration is used if empty
+	//+kubebuilder:validation:XValidation:rule="self == oldSelf",message="projectName is immutable"
+	ProjectName         string                 `json:"projectName,omitempty"`
 	LicenceType         *string                `json:"licenceType,omitempty"`
 	SecretBindingName   string                 `json:"secretBindingName"`
 	EnforceSeedLocation *bool                  `json:"enforceSeedLocation,omitempty"`
@@ -159,11 +317,28 @@ type RuntimeShoot struct {
 	Provider            Provider               `json:"provider"`
 	Networking          Networking             `json:"networking"`
 	ControlPlane        *gardener.ControlPlane `json:"controlPlane,omitempty"`
+	Hibernation         *gardener.Hibernation  `json:"hibernation,omitempty"`
+	Maintenance         *Maintenance           `json:"maintenance,omitempty"`
+}
+
+type Maintenance struct {
+	// TimeWindow is the daily maintenance time window in the Gardener format, e.g. begin "220000+0000" and end "230000+0000".
+	// When not set, the default window configured for the region is used
+	TimeWindow *gardener.MaintenanceTimeWindow `json:"timeWindow,omitempty"`
+	// AutoUpdate overrides the auto-update settings from the converter configuration
+	AutoUpdate *MaintenanceAutoUpdate `json:"autoUpdate,omitempty"`
+}
+
+type MaintenanceAutoUpdate struct {
+	KubernetesVersion   *bool `json:"kubernetesVersion,omitempty"`
+	MachineImageVersion *bool `json:"machineImageVersion,omitempty"`
 }
 
 type Kubernetes struct {
 	Version       *string   `json:"version,omitempty"`
 	KubeAPIServer APIServer `json:"kubeAPIServer,omitempty"`
+	// ClusterAutoscaler overrides the default cluster autoscaler settings
+	ClusterAutoscaler *gardener.ClusterAutoscaler `json:"clusterAutoscaler,omitempty"`
 }
 
 type APIServer struct {
@@ -172,16 +347,120 @@ type APIServer struct {
 }
 
 type Provider struct {
-	//+kubebuilder:validation:Enum=aws;azure;gcp;openstack
+	//+kubebuilder:validation:Enum=aws;azure;gcp;openstack;alicloud
+	//+kubebuilder:validation:XValidation:rule="self == oldSelf",message="provider type is immutable"
 	Type    string            `json:"type"`
 	Workers []gardener.Worker `json:"workers"`
+	// AWS contains the settings specific to the AWS shoots
+	AWS *AWSProviderConfig `json:"aws,omitempty"`
+	// Azure contains the settings specific to the Azure shoots
+	Azure *AzureProviderConfig `json:"azure,omitempty"`
+	// GCP contains the settings specific to the GCP shoots
+	GCP *GCPProviderConfig `json:"gcp,omitempty"`
+	// OpenStack overrides the OpenStack settings configured for the region
+	OpenStack *OpenStackProviderConfig `json:"openstack,omitempty"`
+}
+
+// +kubebuilder:validation:XValidation:rule="has(self.vpcID) == has(oldSelf.vpcID)",message="vpcID is immutable"
+type AWSProviderConfig struct {
+	// VPCID is the ID of an existing VPC the shoot is attached to, a new VPC is created from the nodes CIDR if not set.
+	// The nodes CIDR must be the CIDR of the existing VPC
+	//+kubebuilder:validation:XValidation:rule="self == oldSelf",message="vpcID is immutable"
+	VPCID *string `json:"vpcID,omitempty"`
+}
+
+// +kubebuilder:validation:XValidation:rule="has(self.vnet) == has(oldSelf.vnet)",message="vnet is immutable"
+type AzureProviderConfig struct {
+	// VNet is an existing VNet the shoot is attached to, a new VNet is created from the nodes CIDR if not set.
+	// The nodes CIDR must be the CIDR of the existing VNet
+	//+kubebuilder:validation:XValidation:rule="self == oldSelf",message="vnet is immutable"
+	VNet *AzureVNet `json:"vnet,omitempty"`
+	// NatGateway configures the NAT gateways deployed in the zones of the shoot
+	NatGateway *AzureNatGateway `json:"natGateway,omitempty"`
+}
+
+type AzureVNet struct {
+	// Name is the name of the existing VNet
+	Name string `json:"name"`
+	// ResourceGroup is the resource group of the existing VNet
+	ResourceGroup string `json:"resourceGroup"`
+}
+
+type AzureNatGateway struct {
+	// Enabled deploys the NAT gateway in every zone, defaults to true
+	Enabled *bool `json:"enabled,omitempty"`
+	// IdleConnectionTimeoutMinutes is the idle connection timeout of the NAT gateways, defaults to 4 minutes
+	//+kubebuilder:validation:Minimum=4
+	//+kubebuilder:validation:Maximum=120
+	IdleConnectionTimeoutMinutes *int `json:"idleConnectionTimeoutMinutes,omitempty"`
+	// IPAddresses are the existing public IPs assigned to the NAT gateways of their zones
+	IPAddresses []AzurePublicIPReference `json:"ipAddresses,omitempty"`
+}
+
+type AzurePublicIPReference struct {
+	// Name is the name of the public IP
+	Name string `json:"name"`
+	// ResourceGroup is the resource group of the public IP
+	ResourceGroup string `json:"resourceGroup"`
+	// Zone is the zone the public IP is deployed to, the IP is assigned to the NAT gateway of this zone
+	//+kubebuilder:validation:Minimum=1
+	//+kubebuilder:validation:Maximum=3
+	Zone int32 `json:"zone"`
+}
+
+// +kubebuilder:validation:XValidation:rule="has(self.controlPlaneZone) == has(oldSelf.controlPlaneZone)",message="control plane zone is immutable"
+// +kubebuilder:validation:XValidation:rule="has(self.internal) == has(oldSelf.internal)",message="internal CIDR is immutable"
+type GCPProviderConfig struct {
+	// ControlPlaneZone is the zone of the control plane, it must be one of the worker zones, defaults to the first worker zone
+	//+kubebuilder:validation:XValidation:rule="self == oldSelf",message="control plane zone is immutable"
+	ControlPlaneZone *string `json:"controlPlaneZone,omitempty"`
+	// CloudNAT configures the Cloud NAT of the shoot network
+	CloudNAT *GCPCloudNAT `json:"cloudNAT,omitempty"`
+	// Internal is the CIDR of the internal subnet used by the internal load balancers, not created if not set
+	//+kubebuilder:validation:XValidation:rule="self == oldSelf",message="internal CIDR is immutable"
+	Internal *string `json:"internal,omitempty"`
+}
+
+type GCPCloudNAT struct {
+	// MinPortsPerVM is the minimal number of ports allocated to a VM in the NAT config
+	//+kubebuilder:validation:Minimum=2
+	//+kubebuilder:validation:Maximum=65536
+	MinPortsPerVM *int32 `json:"minPortsPerVM,omitempty"`
+	// NatIPNames are the names of the existing external IP addresses used by the Cloud NAT
+	NatIPNames []string `json:"natIPNames,omitempty"`
+}
+
+// +kubebuilder:validation:XValidation:rule="has(self.floatingPoolName) == has(oldSelf.floatingPoolName)",message="floating pool name is immutable"
+// +kubebuilder:validation:XValidation:rule="has(self.exposureClassName) == has(oldSelf.exposureClassName)",message="exposure class name is immutable"
+type OpenStackProviderConfig struct {
+	// FloatingPoolName is the name of the floating IP pool used by the shoot
+	//+kubebuilder:validation:XValidation:rule="self == oldSelf",message="floating pool name is immutable"
+	FloatingPoolName *string `json:"floatingPoolName,omitempty"`
+	// LoadBalancerProvider is the provider of the load balancers created for the shoot
+	LoadBalancerProvider *string `json:"loadBalancerProvider,omitempty"`
+	// ExposureClassName is the exposure class of the shoot control plane
+	//+kubebuilder:validation:XValidation:rule="self == oldSelf",message="exposure class name is immutable"
+	ExposureClassName *string `json:"exposureClassName,omitempty"`
 }
 
+// +kubebuilder:validation:XValidation:rule="has(self.type) == has(oldSelf.type)",message="networking type is immutable"
+// +kubebuilder:validation:XValidation:rule="has(self.zoneBits) == has(oldSelf.zoneBits)",message="zone bits is immutable"
 type Networking struct {
-	Type     *string `json:"type,omitempty"`
-	Pods     string  `json:"pods"`
-	Nodes    string  `json:"nodes"`
-	Services string  `json:"services"`
+	//+kubebuilder:validation:XValidation:rule="self == oldSelf",message="networking type is immutable"
+	Type *string `json:"type,omitempty"`
+	//+kubebuilder:validation:XValidation:rule="self == oldSelf",message="pods CIDR is immutable"
+	Pods string `json:"pods"`
+	//+kubebuilder:validation:XValidation:rule="self == oldSelf",message="nodes CIDR is immutable"
+	Nodes string `json:"nodes"`
+	//+kubebuilder:validation:XValidation:rule="self == oldSelf",message="services CIDR is immutable"
+	Services string `json:"services"`
+	// ZoneBits is the number of bits added to the prefix length of the nodes CIDR to get the block of a single zone,
+	// so the nodes CIDR holds the subnets of at most 2^zoneBits zones. The layout of the provider is used if not set.
+	// It is set from the configuration when the Runtime is created, and applies only to AWS, Azure and Alicloud
+	//+kubebuilder:validation:Minimum=1
+	//+kubebuilder:validation:Maximum=8
+	//+kubebuilder:validation:XValidation:rule="self == oldSelf",message="zone bits is immutable"
+	ZoneBits *int `json:"zoneBits,omitempty"`
 }
 
 type Security struct {
@@ -222,6 +501,18 @@ func (k *Runtime) UpdateStateReady(c RuntimeConditionType, r RuntimeConditionRea
 	meta.SetStatusCondition(&k.Status.Conditions, condition)
 }
 
+func (k *Runtime) UpdateStateHibernated(c RuntimeConditionType, r RuntimeConditionReason, msg string) {
+	k.Status.State = RuntimeStateHibernated
+	condition := metav1.Condition{
+		Type:               string(c),
+		Status:             "True",
+		LastTransitionTime: metav1.Now(),
+		Reason:             string(r),
+		Message:            msg,
+	}
+	meta.SetStatusCondition(&k.Status.Conditions, condition)
+}
+
 func (k *Runtime) UpdateStateDeletion(c RuntimeConditionType, r RuntimeConditionReason, status, msg string) {
 	if status != "False" {
 		k.Status.State = RuntimeStateTerminating
@@ -312,3 +603,23 @@ func (k *Runtime) IsControlledByProvisioner() bool {
 	value, found := k.Labels[LabelControlledByProvisioner]
 	return !found || value != "false"
 }
+
+func (k *Runtime) IsDeletionProtected() bool {
+	return k.Annotations[AnnotationDeletionProtection] == "true"
+}
+
+func (k *Runtime) IsOrphanedOnDeletion() bool {
+	return k.Spec.Deletion != nil && k.Spec.Deletion.Policy == DeletionPolicyOrphan
+}
+
+func (k *Runtime) IsDriftCorrected() bool {
+	return k.Spec.Drift != nil && k.Spec.Drift.Policy == DriftPolicyCorrect
+}
+
+func (k *Runtime) IsPlanRequested() bool {
+	return k.Annotations[AnnotationPlan] == "true"
+}
+
+func (k *Runtime) IsMarkedForAdoption() bool {
+	return k.Annotations[AnnotationAdopt] == "true"
+}
@@ -34,6 +34,7 @@ import (
 	"github.com/kyma-project/infrastructure-manager/internal/controller/metrics"
 	runtime_controller "github.com/kyma-project/infrastructure-manager/internal/controller/runtime"
 	"github.com/kyma-project/infrastructure-manager/internal/controller/runtime/fsm"
+	runtime_webhook "github.com/kyma-project/infrastructure-manager/internal/webhook/runtime"
 	"github.com/kyma-project/infrastructure-manager/pkg/config"
 	"github.com/kyma-project/infrastructure-manager/pkg/gardener"
 	"github.com/kyma-project/infrastructure-manager/pkg/gardener/kubeconfig"
@@ -44,7 +45,9 @@ import (
 	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
 	_ "k8s.io/client-go/plugin/pkg/client/auth"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/cluster"
 	"sigs.k8s.io/controller-runtime/pkg/healthz"
 	"sigs.k8s.io/controller-runtime/pkg/log/zap"
 	metricsserver "sigs.k8s.io/controller-runtime/pkg/metrics/server"
@@ -67,7 +70,11 @@ const defaultMinimalRotationTimeRatio = 0.6
 const defaultExpirationTime = 24 * time.Hour
 const defaultGardenerRequestTimeout = 60 * time.Second
 const defaultControlPlaneRequeueDuration = 10 * time.Second
-const defaultGardenerRequeueDuration = 15 * time.Second
+const defaultGardenerRequeueDuration = 1 * time.Minute
+const defaultRetryBaseDelay = 30 * time.Second
+const defaultRetryMaxDelay = 30 * time.Minute
+const defaultRetryMaxAttempts = 10
+const defaultRetryJitterFactor = 0.2
 
 func main() {
 	var metricsAddr string
@@ -78,9 +85,13 @@ func main() {
 	var minimalRotationTimeRatio float64
 	var expirationTime time.Duration
 	var gardenerRequestTimeout time.Duration
+	var gardenerRequeueDuration time.Duration
 	var converterConfigFilepath string
 	var shootSpecDumpEnabled bool
 	var auditLogMandatory bool
+	var webhooksEnabled bool
+	var deletionGracePeriod time.Duration
+	var driftCheckInterval time.Duration
 
 	flag.StringVar(&metricsAddr, "metrics-bind-address", ":8080", "The address the metric endpoint binds to.")
 	flag.StringVar(&probeAddr, "health-probe-bind-address", ":8081", "The address the probe endpoint binds to.")
@@ -92,9 +103,13 @@ func main() {
 	flag.Float64Var(&minimalRotationTimeRatio, "minimal-rotation-time", defaultMinimalRotationTimeRatio, "The ratio determines what is the minimal time that needs to pass to rotate certificate.")
 	flag.DurationVar(&expirationTime, "kubeconfig-expiration-time", defaultExpirationTime, "Dynamic kubeconfig expiration time")
 	flag.DurationVar(&gardenerRequestTimeout, "gardener-request-timeout", defaultGardenerRequestTimeout, "Timeout duration for requests to Gardener")
+	flag.DurationVar(&gardenerRequeueDuration, "gardener-requeue-duration", defaultGardenerRequeueDuration, "Fallback requeue duration used while waiting for Gardener shoot operations, shoot changes are watched")
 	flag.StringVar(&converterConfigFilepath, "converter-config-filepath", "/converter-config/converter_config.json", "A file path to the gardener shoot converter configuration.")
 	flag.BoolVar(&shootSpecDumpEnabled, "shoot-spec-dump-enabled", false, "Feature flag to allow persisting specs of created shoots")
 	flag.BoolVar(&auditLogMandatory, "audit-log-mandatory", true, "Feature flag to enable strict mode for audit log configuration")
+	flag.BoolVar(&webhooksEnabled, "webhooks-enabled", false, "Feature flag to enable admission webhooks for Runtime CRs")
+	flag.DurationVar(&deletionGracePeriod, "runtime-deletion-grace-period", 0, "Time after the Runtime deletion during which the shoot deletion can be cancelled with the deletion protection annotation")
+	flag.DurationVar(&driftCheckInterval, "drift-check-interval", 0, "Interval of comparing Runtime CRs with their shoots to detect changes done directly in Gardener, 0 disables the drift detection")
 
 	opts := zap.Options{
 		Development: true,
@@ -131,16 +146,49 @@ func main() {
 		os.Exit(1)
 	}
 
+	// load converter configuration
+	getReader := func() (io.Reader, error) {
+		return os.Open(converterConfigFilepath)
+	}
+	var config config.Config
+	if err = config.Load(getReader); err != nil {
+		setupLog.Error(err, "unable to load converter configuration")
+		os.Exit(1)
+	}
+
+	validate := validator.New(validator.WithRequiredStructEnabled())
+	if err = validate.Struct(config); err != nil {
+		setupLog.Error(err, "invalid converter configuration")
+		os.Exit(1)
+	}
+
+	err = validateAuditLogConfiguration(config.ConverterConfig.AuditLog.TenantConfigPath)
+	if err != nil {
+		setupLog.Error(err, "invalid Audit Log configuration")
+		os.Exit(1)
+	}
+
 	gardenerNamespace := fmt.Sprintf("garden-%s", gardenerProjectName)
-	gardenerClient, shootClient, dynamicKubeconfigClient, err := initGardenerClients(gardenerKubeconfigPath, gardenerNamespace)
+	gardenerClient, shootClients, dynamicKubeconfigClient, err := initGardenerClients(gardenerKubeconfigPath)
 
 	if err != nil {
 		setupLog.Error(err, "unable to initialize gardener clients", "controller", "GardenerCluster")
 		os.Exit(1)
 	}
 
+	gardenerCluster, err := initGardenerCluster(gardenerKubeconfigPath, getGardenerNamespaces(gardenerNamespace, config.ConverterConfig.Gardener))
+	if err != nil {
+		setupLog.Error(err, "unable to initialize gardener cluster", "controller", "Runtime")
+		os.Exit(1)
+	}
+
+	if err = mgr.Add(gardenerCluster); err != nil {
+		setupLog.Error(err, "unable to add gardener cluster to manager", "controller", "Runtime")
+		os.Exit(1)
+	}
+
 	kubeconfigProvider := kubeconfig.NewKubeconfigProvider(
-		shootClient,
+		shootClients,
 		dynamicKubeconfigClient,
 		gardenerNamespace,
 		int64(expirationTime.Seconds()))
@@ -160,30 +208,8 @@ func main() {
 		os.Exit(1)
 	}
 
-	// load converter configuration
-	getReader := func() (io.Reader, error) {
-		return os.Open(converterConfigFilepath)
-	}
-	var config config.Config
-	if err = config.Load(getReader); err != nil {
-		setupLog.Error(err, "unable to load converter configuration")
-		os.Exit(1)
-	}
-
-	validate := validator.New(validator.WithRequiredStructEnabled())
-	if err = validate.Struct(config); err != nil {
-		setupLog.Error(err, "invalid converter configuration")
-		os.Exit(1)
-	}
-
-	err = validateAuditLogConfiguration(config.ConverterConfig.AuditLog.TenantConfigPath)
-	if err != nil {
-		setupLog.Error(err, "invalid Audit Log configuration")
-		os.Exit(1)
-	}
-
 	cfg := fsm.RCCfg{
-		GardenerRequeueDuration:     defaultGardenerRequeueDuration,
+		GardenerRequeueDuration:     gardenerRequeueDuration,
 		ControlPlaneRequeueDuration: defaultControlPlaneRequeueDuration,
 		Finalizer:                   infrastructuremanagerv1.Finalizer,
 		ShootNamesapace:             gardenerNamespace,
@@ -191,6 +217,14 @@ func main() {
 		AuditLogMandatory:           auditLogMandatory,
 		Metrics:                     metrics,
 		AuditLogging:                auditlogging.NewAuditLogging(config.ConverterConfig.AuditLog.TenantConfigPath, config.ConverterConfig.AuditLog.PolicyConfigMapName, gardenerClient),
+		RetryPolicy: fsm.RetryPolicy{
+			BaseDelay:    defaultRetryBaseDelay,
+			MaxDelay:     defaultRetryMaxDelay,
+			MaxAttempts:  defaultRetryMaxAttempts,
+			JitterFactor: defaultRetryJitterFactor,
+		},
+		DeletionGracePeriod: deletionGracePeriod,
+		DriftCheckInterval:  driftCheckInterval,
 	}
 	if shootSpecDumpEnabled {
 		cfg.PVCPath = "/testdata/kim"
@@ -203,11 +237,18 @@ func main() {
 		cfg,
 	)
 
-	if err = runtimeReconciler.SetupWithManager(mgr); err != nil {
+	if err = runtimeReconciler.SetupWithManager(mgr, gardenerCluster); err != nil {
 		setupLog.Error(err, "unable to setup controller with Manager", "controller", "Runtime")
 		os.Exit(1)
 	}
 
+	if webhooksEnabled {
+		if err = runtime_webhook.SetupRuntimeWebhookWithManager(mgr, config); err != nil {
+			setupLog.Error(err, "unable to create webhook", "webhook", "Runtime")
+			os.Exit(1)
+		}
+	}
+
 	//+kubebuilder:scaffold:builder
 
 	if err = mgr.AddHealthzCheck("healthz", healthz.Ping); err != nil {
@@ -227,7 +268,7 @@ func main() {
 	}
 }
 
-func initGardenerClients(kubeconfigPath string, namespace string) (client.Client, gardener_apis.ShootInterface, client.SubResourceClient, error) {
+func initGardenerClients(kubeconfigPath string) (client.Client, kubeconfig.ShootClientFactory, client.SubResourceClient, error) {
 	restConfig, err := gardener.NewRestConfigFromFile(kubeconfigPath)
 	if err != nil {
 		return nil, nil, nil, err
@@ -253,10 +294,47 @@ func initGardenerClients(kubeconfigPath string, namespace string) (client.Client
 		return nil, nil, nil, errors.Wrap(err, "failed to register Gardener schema")
 	}
 
-	shootClient := gardenerClientSet.Shoots(namespace)
+	shootClients := func(namespace string) kubeconfig.ShootClient {
+		return gardenerClientSet.Shoots(namespace)
+	}
 	dynamicKubeconfigAPI := gardenerClient.SubResource("adminkubeconfig")
 
-	return gardenerClient, shootClient, dynamicKubeconfigAPI, nil
+	return gardenerClient, shootClients, dynamicKubeconfigAPI, nil
+}
+
+// initGardenerCluster creates a cluster with a cache of the shoots in the Gardener project namespaces, used to watch the shoots
+func initGardenerCluster(kubeconfigPath string, namespaces []string) (cluster.Cluster, error) {
+	restConfig, err := gardener.NewRestConfigFromFile(kubeconfigPath)
+	if err != nil {
+		return nil, err
+	}
+
+	gardenerScheme := runtime.NewScheme()
+	if err = v1beta1.AddToScheme(gardenerScheme); err != nil {
+		return nil, errors.Wrap(err, "failed to register Gardener schema")
+	}
+
+	defaultNamespaces := map[string]cache.Config{}
+	for _, namespace := range namespaces {
+		defaultNamespaces[namespace] = cache.Config{}
+	}
+
+	return cluster.New(restConfig, func(o *cluster.Options) {
+		o.Scheme = gardenerScheme
+		o.Cache = cache.Options{
+			DefaultNamespaces: defaultNamespaces,
+		}
+	})
+}
+
+// getGardenerNamespaces returns the namespaces of the default Gardener project and of the projects which can be selected in the Runtime
+func getGardenerNamespaces(defaultNamespace string, gardenerConfig config.GardenerConfig) []string {
+	namespaces := []string{defaultNamespace}
+	for _, projectName := range gardenerConfig.ProjectNames() {
+		namespaces = append(namespaces, fmt.Sprintf("garden-%s", projectName))
+	}
+
+	return namespaces
 }
 
 func validateAuditLogConfiguration(tenantConfigPath string) error {
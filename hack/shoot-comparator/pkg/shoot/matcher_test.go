@@ -484,7 +484,6 @@ var _ = Describe(":: shoot matcher :: ", func() {
 						KubernetesVersion:   true,
 						MachineImageVersion: ptr.To[bool](true),
 					},
-					TimeWindow: &v1beta1.MaintenanceTimeWindow{},
 				},
 			})),
 			deepCp(empty, withShootSpec(v1beta1.ShootSpec{
@@ -493,11 +492,26 @@ var _ = Describe(":: shoot matcher :: ", func() {
 						KubernetesVersion:   true,
 						MachineImageVersion: ptr.To[bool](true),
 					},
+					TimeWindow:               &v1beta1.MaintenanceTimeWindow{},
 					ConfineSpecUpdateRollout: ptr.To[bool](true),
 				},
 			})),
 			true,
 		),
+		Entry(
+			"should find differences in spec/maintenance/timeWindow",
+			deepCp(empty, withShootSpec(v1beta1.ShootSpec{
+				Maintenance: &v1beta1.Maintenance{
+					TimeWindow: &v1beta1.MaintenanceTimeWindow{Begin: "220000+0000", End: "230000+0000"},
+				},
+			})),
+			deepCp(empty, withShootSpec(v1beta1.ShootSpec{
+				Maintenance: &v1beta1.Maintenance{
+					TimeWindow: &v1beta1.MaintenanceTimeWindow{Begin: "010000+0000", End: "020000+0000"},
+				},
+			})),
+			false,
+		),
 		Entry(
 			"should find differences in spec/networking",
 			deepCp(empty, withShootSpec(v1beta1.ShootSpec{})),
@@ -74,7 +74,7 @@ func NewGardenerClusterController(mgr ctrl.Manager, kubeconfigProvider Kubeconfi
 //
 //go:generate mockery --name=KubeconfigProvider
 type KubeconfigProvider interface {
-	Fetch(ctx context.Context, shootName string) (string, error)
+	Fetch(ctx context.Context, shootName, shootNamespace string) (string, error)
 }
 
 //+kubebuilder:rbac:groups=infrastructuremanager.kyma-project.io,resources=gardenerclusters,verbs=get;list;watch;create;update;patch;delete
@@ -257,7 +257,7 @@ const (
 )
 
 func (controller *GardenerClusterController) handleKubeconfig(ctx context.Context, secret *corev1.Secret, cluster *imv1.GardenerCluster, now time.Time) (kubeconfigStatus, error) {
-	kubeconfig, err := controller.KubeconfigProvider.Fetch(ctx, cluster.Spec.Shoot.Name)
+	kubeconfig, err := controller.KubeconfigProvider.Fetch(ctx, cluster.Spec.Shoot.Name, cluster.Spec.Shoot.Namespace)
 	if err != nil {
 		cluster.UpdateConditionForErrorState(imv1.ConditionTypeKubeconfigManagement, imv1.ConditionReasonFailedToGetKubeconfig, err)
 		return ksZero, err
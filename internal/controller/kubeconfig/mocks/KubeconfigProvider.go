@@ -13,9 +13,9 @@ type KubeconfigProvider struct {
 	mock.Mock
 }
 
-// Fetch provides a mock function with given fields: ctx, shootName
-func (_m *KubeconfigProvider) Fetch(ctx context.Context, shootName string) (string, error) {
-	ret := _m.Called(ctx, shootName)
+// Fetch provides a mock function with given fields: ctx, shootName, shootNamespace
+func (_m *KubeconfigProvider) Fetch(ctx context.Context, shootName string, shootNamespace string) (string, error) {
+	ret := _m.Called(ctx, shootName, shootNamespace)
 
 	if len(ret) == 0 {
 		panic("no return value specified for Fetch")
@@ -23,17 +23,17 @@ func (_m *KubeconfigProvider) Fetch(ctx context.Context, shootName string) (stri
 
 	var r0 string
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, string) (string, error)); ok {
-		return rf(ctx, shootName)
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) (string, error)); ok {
+		return rf(ctx, shootName, shootNamespace)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, string) string); ok {
-		r0 = rf(ctx, shootName)
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) string); ok {
+		r0 = rf(ctx, shootName, shootNamespace)
 	} else {
 		r0 = ret.Get(0).(string)
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
-		r1 = rf(ctx, shootName)
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, shootName, shootNamespace)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -113,12 +113,12 @@ var _ = BeforeSuite(func() {
 })
 
 func setupKubeconfigProviderMock(kpMock *kubeconfig_mocks.KubeconfigProvider) {
-	kpMock.On("Fetch", anyContext, "shootName1").Return("kubeconfig1", nil)
-	kpMock.On("Fetch", anyContext, "shootName2").Return("kubeconfig2", nil)
-	kpMock.On("Fetch", anyContext, "shootName3").Return("", errors.New("this could be context deadline exceeded"))
-	kpMock.On("Fetch", anyContext, "shootName6").Return("kubeconfig6", nil)
-	kpMock.On("Fetch", anyContext, "shootName4").Return("kubeconfig4", nil)
-	kpMock.On("Fetch", anyContext, "shootName5").Return("kubeconfig5", nil)
+	kpMock.On("Fetch", anyContext, "shootName1", "").Return("kubeconfig1", nil)
+	kpMock.On("Fetch", anyContext, "shootName2", "").Return("kubeconfig2", nil)
+	kpMock.On("Fetch", anyContext, "shootName3", "").Return("", errors.New("this could be context deadline exceeded"))
+	kpMock.On("Fetch", anyContext, "shootName6", "").Return("kubeconfig6", nil)
+	kpMock.On("Fetch", anyContext, "shootName4", "").Return("kubeconfig4", nil)
+	kpMock.On("Fetch", anyContext, "shootName5", "").Return("kubeconfig5", nil)
 }
 
 var _ = AfterSuite(func() {
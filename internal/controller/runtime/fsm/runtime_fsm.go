@@ -34,6 +34,11 @@ type RCCfg struct {
 	AuditLogMandatory           bool
 	Metrics                     metrics.Metrics
 	AuditLogging                auditlogging.AuditLogging
+	RetryPolicy                 RetryPolicy
+	// DeletionGracePeriod delays the deletion of the shoot after the Runtime is deleted, so the deletion can be cancelled
+	DeletionGracePeriod time.Duration
+	// DriftCheckInterval is the period of comparing the Runtime with the shoot, 0 disables the drift detection
+	DriftCheckInterval time.Duration
 	config.Config
 }
 
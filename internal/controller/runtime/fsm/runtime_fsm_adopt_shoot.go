@@ -0,0 +1,136 @@
+package fsm
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	gardener "github.com/gardener/gardener/pkg/apis/core/v1beta1"
+	imv1 "github.com/kyma-project/infrastructure-manager/api/v1"
+	gardener_shoot "github.com/kyma-project/infrastructure-manager/pkg/gardener/shoot"
+	"github.com/kyma-project/infrastructure-manager/pkg/gardener/shoot/drift"
+	"github.com/kyma-project/infrastructure-manager/pkg/gardener/shoot/extender"
+	"k8s.io/apimachinery/pkg/api/equality"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// sFnAdoptShoot takes over the existing shoot for the Runtime marked for adoption.
+// The Runtime spec is back-filled from the shoot, and the shoot is adopted only if the conversion of the Runtime reproduces it,
+// so that the later patches do not change the running cluster
+func sFnAdoptShoot(ctx context.Context, m *fsm, s *systemState) (stateFn, *ctrl.Result, error) {
+	m.log.Info("adopt shoot state")
+
+	if s.shoot == nil || !s.shoot.GetDeletionTimestamp().IsZero() {
+		m.log.Info("Shoot to adopt does not exist, exiting with no retry", "Name", s.instance.Spec.Shoot.Name)
+		m.Metrics.IncRuntimeFSMStopCounter()
+		return updateStatePendingWithErrorAndStop(&s.instance, imv1.ConditionTypeRuntimeProvisioned, imv1.ConditionReasonAdoptionErr, fmt.Sprintf("Shoot %s to adopt not found", s.instance.Spec.Shoot.Name))
+	}
+
+	if runtimeID, found := s.shoot.GetAnnotations()[extender.ShootRuntimeIDAnnotation]; found && runtimeID != s.instance.Labels[imv1.LabelKymaRuntimeID] {
+		m.log.Info("Shoot to adopt belongs to other Runtime, exiting with no retry", "Name", s.shoot.Name, "runtimeID", runtimeID)
+		m.Metrics.IncRuntimeFSMStopCounter()
+		return updateStatePendingWithErrorAndStop(&s.instance, imv1.ConditionTypeRuntimeProvisioned, imv1.ConditionReasonAdoptionErr, fmt.Sprintf("Shoot %s to adopt belongs to Runtime %s", s.shoot.Name, runtimeID))
+	}
+
+	backfilled := s.instance.DeepCopy()
+	gardener_shoot.BackfillRuntime(backfilled, *s.shoot)
+	if !equality.Semantic.DeepEqual(backfilled.Spec, s.instance.Spec) {
+		m.log.Info("back-filling Runtime from shoot", "Name", s.shoot.Name, "Namespace", s.shoot.Namespace)
+		s.instance.Spec = backfilled.Spec
+
+		if err := m.Update(ctx, &s.instance); err != nil {
+			return updateStatusAndStopWithError(err)
+		}
+		return requeue()
+	}
+
+	if err := loadCloudProfile(ctx, m, s); err != nil {
+		m.log.Error(err, "Failed to get cloud profile")
+		return retryOrStop(m, s, isRetryableAPIError(err), imv1.ConditionTypeRuntimeProvisioned, imv1.ConditionReasonGardenerError, fmt.Sprintf("Cloud profile read error: %v", err))
+	}
+
+	desiredShoot, err := convertShoot(&s.instance, m.Config.ConverterConfig, s.cloudProfile)
+	if err != nil {
+		m.log.Error(err, "Failed to convert Runtime instance to shoot object, exiting with no retry")
+		m.Metrics.IncRuntimeFSMStopCounter()
+		return updateStatePendingWithErrorAndStop(&s.instance, imv1.ConditionTypeRuntimeProvisioned, imv1.ConditionReasonConversionError, fmt.Sprintf("Runtime conversion error: %v", err))
+	}
+
+	// the fields of the shoot not set by the conversion are kept by the later patches, so the result of the patch is compared
+	if err = dryRunPatchShoot(ctx, m, &desiredShoot); err != nil {
+		m.log.Error(err, "Failed to patch shoot object in dry-run mode")
+		return retryOrStop(m, s, isRetryableAPIError(err), imv1.ConditionTypeRuntimeProvisioned, imv1.ConditionReasonGardenerError, fmt.Sprintf("Shoot dry-run patch error: %v", err))
+	}
+
+	differences, err := drift.Compare(desiredShoot, *s.shoot)
+	if err != nil {
+		m.log.Error(err, "Failed to compare shoot with Runtime, exiting with no retry")
+		m.Metrics.IncRuntimeFSMStopCounter()
+		return updateStatePendingWithErrorAndStop(&s.instance, imv1.ConditionTypeRuntimeProvisioned, imv1.ConditionReasonAdoptionErr, fmt.Sprintf("Shoot comparison error: %v", err))
+	}
+
+	if len(differences) > 0 {
+		m.log.Info("Shoot differs from Runtime, exiting with no retry", "Name", s.shoot.Name, "differences", differences)
+		m.Metrics.IncRuntimeFSMStopCounter()
+		return updateStatePendingWithErrorAndStop(&s.instance, imv1.ConditionTypeRuntimeProvisioned, imv1.ConditionReasonAdoptionErr, fmt.Sprintf("Shoot differs from Runtime: %s", formatDifferences(differences)))
+	}
+
+	m.log.Info("taking ownership of shoot", "Name", s.shoot.Name, "Namespace", s.shoot.Namespace)
+	original := s.shoot.DeepCopy()
+	s.shoot.Annotations = withOwnershipAnnotations(s.shoot.Annotations, s.instance)
+
+	if err := m.ShootClient.Patch(ctx, s.shoot, client.MergeFrom(original)); err != nil {
+		m.log.Error(err, "unable to patch shoot:", s.shoot.Name)
+		return retryOrStop(m, s, isRetryableAPIError(err), imv1.ConditionTypeRuntimeProvisioned, imv1.ConditionReasonGardenerError, fmt.Sprintf("Shoot patch error: %v", err))
+	}
+
+	resetRetries(s)
+	s.markGenerationApplied()
+	s.instance.UpdateStatePending(
+		imv1.ConditionTypeRuntimeProvisioned,
+		imv1.ConditionReasonShootAdopted,
+		"Unknown",
+		"Shoot adopted",
+	)
+
+	return updateStatusAndRequeue()
+}
+
+// isShootAdopted returns true if the shoot is already managed by the controller for the Runtime
+func isShootAdopted(shoot *gardener.Shoot, instance imv1.Runtime) bool {
+	annotations := shoot.GetAnnotations()
+	if _, found := annotations[extender.ShootRuntimeGenerationAnnotation]; !found {
+		return false
+	}
+
+	return annotations[extender.ShootRuntimeIDAnnotation] == instance.Labels[imv1.LabelKymaRuntimeID]
+}
+
+func withOwnershipAnnotations(annotations map[string]string, instance imv1.Runtime) map[string]string {
+	result := make(map[string]string, len(annotations)+2)
+	for key, value := range annotations {
+		result[key] = value
+	}
+
+	result[extender.ShootRuntimeIDAnnotation] = instance.Labels[imv1.LabelKymaRuntimeID]
+	result[extender.ShootRuntimeGenerationAnnotation] = fmt.Sprintf("%d", instance.Generation)
+
+	return result
+}
+
+// formatDifferences keeps the condition message short, all the differences are logged
+func formatDifferences(differences []drift.Difference) string {
+	const maxReported = 5
+
+	messages := make([]string, 0, maxReported)
+	for i, difference := range differences {
+		if i == maxReported {
+			messages = append(messages, fmt.Sprintf("and %d more", len(differences)-maxReported))
+			break
+		}
+		messages = append(messages, difference.String())
+	}
+
+	return strings.Join(messages, "; ")
+}
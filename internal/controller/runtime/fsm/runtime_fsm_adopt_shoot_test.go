@@ -0,0 +1,223 @@
+package fsm
+
+import (
+	"context"
+	"testing"
+
+	gardener "github.com/gardener/gardener/pkg/apis/core/v1beta1"
+	imv1 "github.com/kyma-project/infrastructure-manager/api/v1"
+	"github.com/kyma-project/infrastructure-manager/internal/controller/metrics/mocks"
+	"github.com/kyma-project/infrastructure-manager/pkg/config"
+	gardener_shoot "github.com/kyma-project/infrastructure-manager/pkg/gardener/shoot"
+	"github.com/kyma-project/infrastructure-manager/pkg/gardener/shoot/extender"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+)
+
+func TestAdoptShoot(t *testing.T) {
+	converterConfig := config.ConverterConfig{
+		Kubernetes: config.KubernetesConfig{DefaultVersion: "1.30"},
+		DNS: config.DNSConfig{
+			SecretName:   "dns-secret",
+			DomainPrefix: "dev.kyma.ondemand.com",
+			ProviderType: "aws-route53",
+		},
+		MachineImage: config.MachineImageConfig{DefaultName: "gardenlinux"},
+		Gardener:     config.GardenerConfig{ProjectName: "kyma-dev"},
+	}
+
+	cloudProfile := &gardener.CloudProfile{
+		ObjectMeta: metav1.ObjectMeta{Name: extender.DefaultAWSCloudProfileName},
+		Spec: gardener.CloudProfileSpec{
+			MachineImages: []gardener.MachineImage{
+				{
+					Name:     "gardenlinux",
+					Versions: []gardener.MachineImageVersion{{ExpirableVersion: gardener.ExpirableVersion{Version: "1592.1.0"}}},
+				},
+			},
+		},
+	}
+
+	// the Runtime which created the live shoot
+	fixFullRuntime := func() imv1.Runtime {
+		runtime := runtimeForTest()
+		runtime.Labels = map[string]string{
+			imv1.LabelKymaInstanceID:      "instance-id",
+			imv1.LabelKymaRuntimeID:       "runtime-id",
+			imv1.LabelKymaRegion:          "region",
+			imv1.LabelKymaName:            "kyma-name",
+			imv1.LabelKymaBrokerPlanID:    "plan-id",
+			imv1.LabelKymaBrokerPlanName:  "aws",
+			imv1.LabelKymaGlobalAccountID: "global-account-id",
+			imv1.LabelKymaSubaccountID:    "subaccount-id",
+		}
+		runtime.Annotations = map[string]string{imv1.AnnotationAdopt: "true"}
+		runtime.Spec.Shoot.Purpose = "production"
+		runtime.Spec.Shoot.SecretBindingName = "secret-binding"
+		runtime.Spec.Shoot.Kubernetes.Version = ptr.To("1.29")
+		runtime.Spec.Shoot.Networking = imv1.Networking{
+			Nodes:    "10.250.0.0/16",
+			Pods:     "100.64.0.0/12",
+			Services: "100.104.0.0/13",
+		}
+		runtime.Spec.Shoot.Provider.Workers = []gardener.Worker{
+			{
+				Name: "worker",
+				Machine: gardener.Machine{
+					Type:  "m6i.large",
+					Image: &gardener.ShootMachineImage{Name: "gardenlinux", Version: ptr.To("1592.1.0")},
+				},
+				Minimum: 1,
+				Maximum: 3,
+				Zones:   []string{"eu-central-1a"},
+			},
+		}
+		return runtime
+	}
+
+	// the Runtime created for the adoption, with only the immutable fields set
+	fixAdoptingRuntime := func() imv1.Runtime {
+		runtime := fixFullRuntime()
+		runtime.Spec.Shoot.Purpose = ""
+		runtime.Spec.Shoot.SecretBindingName = ""
+		runtime.Spec.Shoot.Kubernetes.Version = nil
+		runtime.Spec.Shoot.Provider.Workers = nil
+		return runtime
+	}
+
+	fixLiveShoot := func(t *testing.T) *gardener.Shoot {
+		fullRuntime := fixFullRuntime()
+		shoot, err := convertShoot(&fullRuntime, converterConfig, cloudProfile)
+		require.NoError(t, err)
+		// the shoot was not created by the controller
+		shoot.Annotations = nil
+		return &shoot
+	}
+
+	scheme, err := newTestScheme()
+	require.NoError(t, err)
+	require.NoError(t, gardener.AddToScheme(scheme))
+
+	metrics := &mocks.Metrics{}
+	metrics.On("IncRuntimeFSMStopCounter").Return().Maybe()
+
+	// the fake client does not support server-side apply, the dry-run returns the applied shoot
+	dryRunPatch := interceptor.Funcs{
+		Patch: func(ctx context.Context, c client.WithWatch, obj client.Object, patch client.Patch, opts ...client.PatchOption) error {
+			if patch == client.Apply {
+				return nil
+			}
+			return c.Patch(ctx, obj, patch, opts...)
+		},
+	}
+
+	t.Run("Should back-fill Runtime and take ownership of shoot", func(t *testing.T) {
+		// given
+		runtime := fixAdoptingRuntime()
+		shoot := fixLiveShoot(t)
+		fsm, err := newFakeFSM(withFakedK8sClientInterceptor(dryRunPatch, scheme, &runtime, shoot, cloudProfile), withMetrics(metrics), withConverterConfig(converterConfig))
+		require.NoError(t, err)
+		state := &systemState{instance: runtime, shoot: shoot.DeepCopy()}
+
+		// when
+		stateFn, result, err := sFnAdoptShoot(context.Background(), fsm, state)
+
+		// then
+		require.NoError(t, err)
+		assert.Nil(t, stateFn)
+		require.NotNil(t, result)
+		assert.True(t, result.Requeue)
+
+		var backfilledRuntime imv1.Runtime
+		require.NoError(t, fsm.Get(context.Background(), client.ObjectKeyFromObject(&runtime), &backfilledRuntime))
+		assert.Equal(t, ptr.To("1.29"), backfilledRuntime.Spec.Shoot.Kubernetes.Version)
+		assert.Equal(t, "secret-binding", backfilledRuntime.Spec.Shoot.SecretBindingName)
+		require.Len(t, backfilledRuntime.Spec.Shoot.Provider.Workers, 1)
+
+		// when
+		state = &systemState{instance: backfilledRuntime, shoot: shoot.DeepCopy()}
+		stateFn, _, err = sFnAdoptShoot(context.Background(), fsm, state)
+
+		// then
+		require.NoError(t, err)
+		require.Contains(t, stateFn.name(), "sFnUpdateStatus")
+		assert.True(t, state.instance.IsConditionSet(imv1.ConditionTypeRuntimeProvisioned, imv1.ConditionReasonShootAdopted))
+
+		var adoptedShoot gardener.Shoot
+		require.NoError(t, fsm.ShootClient.Get(context.Background(), client.ObjectKeyFromObject(shoot), &adoptedShoot))
+		assert.Equal(t, "runtime-id", adoptedShoot.Annotations[extender.ShootRuntimeIDAnnotation])
+		assert.Equal(t, "0", adoptedShoot.Annotations[extender.ShootRuntimeGenerationAnnotation])
+		assert.True(t, isShootAdopted(&adoptedShoot, backfilledRuntime))
+	})
+
+	t.Run("Should refuse adoption of shoot differing from Runtime", func(t *testing.T) {
+		// given
+		runtime := fixFullRuntime()
+		shoot := fixLiveShoot(t)
+		shoot.Spec.Provider.Workers[0].Maximum = 10
+		fsm, err := newFakeFSM(withFakedK8sClientInterceptor(dryRunPatch, scheme, &runtime, shoot, cloudProfile), withMetrics(metrics), withConverterConfig(converterConfig))
+		require.NoError(t, err)
+
+		// the workers set in Runtime are not back-filled
+		gardener_shoot.BackfillRuntime(&runtime, *shoot)
+		state := &systemState{instance: runtime, shoot: shoot.DeepCopy()}
+
+		// when
+		stateFn, _, err := sFnAdoptShoot(context.Background(), fsm, state)
+
+		// then
+		require.NoError(t, err)
+		require.Contains(t, stateFn.name(), "sFnUpdateStatus")
+		assert.True(t, state.instance.IsConditionSetWithStatus(imv1.ConditionTypeRuntimeProvisioned, imv1.ConditionReasonAdoptionErr, metav1.ConditionFalse))
+
+		var actualShoot gardener.Shoot
+		require.NoError(t, fsm.ShootClient.Get(context.Background(), client.ObjectKeyFromObject(shoot), &actualShoot))
+		assert.False(t, isShootAdopted(&actualShoot, runtime))
+	})
+
+	t.Run("Should refuse adoption of shoot belonging to other Runtime", func(t *testing.T) {
+		// given
+		runtime := fixFullRuntime()
+		shoot := fixLiveShoot(t)
+		shoot.Annotations = map[string]string{
+			extender.ShootRuntimeIDAnnotation:         "other-runtime-id",
+			extender.ShootRuntimeGenerationAnnotation: "1",
+		}
+		fsm, err := newFakeFSM(withFakedK8sClientInterceptor(dryRunPatch, scheme, &runtime, shoot, cloudProfile), withMetrics(metrics), withConverterConfig(converterConfig))
+		require.NoError(t, err)
+		state := &systemState{instance: runtime, shoot: shoot.DeepCopy()}
+
+		// when
+		stateFn, _, err := sFnAdoptShoot(context.Background(), fsm, state)
+
+		// then
+		require.NoError(t, err)
+		require.Contains(t, stateFn.name(), "sFnUpdateStatus")
+		assert.True(t, state.instance.IsConditionSetWithStatus(imv1.ConditionTypeRuntimeProvisioned, imv1.ConditionReasonAdoptionErr, metav1.ConditionFalse))
+		assert.False(t, isShootAdopted(shoot, runtime))
+
+		var actualShoot gardener.Shoot
+		require.NoError(t, fsm.ShootClient.Get(context.Background(), client.ObjectKeyFromObject(shoot), &actualShoot))
+		assert.Equal(t, "other-runtime-id", actualShoot.Annotations[extender.ShootRuntimeIDAnnotation])
+	})
+
+	t.Run("Should fail when shoot to adopt does not exist", func(t *testing.T) {
+		// given
+		runtime := fixAdoptingRuntime()
+		fsm, err := newFakeFSM(withFakedK8sClientInterceptor(dryRunPatch, scheme, &runtime), withMetrics(metrics), withConverterConfig(converterConfig))
+		require.NoError(t, err)
+		state := &systemState{instance: runtime}
+
+		// when
+		stateFn, _, err := sFnAdoptShoot(context.Background(), fsm, state)
+
+		// then
+		require.NoError(t, err)
+		require.Contains(t, stateFn.name(), "sFnUpdateStatus")
+		assert.True(t, state.instance.IsConditionSetWithStatus(imv1.ConditionTypeRuntimeProvisioned, imv1.ConditionReasonAdoptionErr, metav1.ConditionFalse))
+	})
+}
@@ -0,0 +1,57 @@
+package fsm
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	imv1 "github.com/kyma-project/infrastructure-manager/api/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+)
+
+// sFnCheckDeletion holds off the deletion of the shoot while the Runtime is protected or the deletion grace period has not elapsed,
+// the shoot of the Runtime with the Orphan deletion policy is kept. Once the deletion of the shoot has started it cannot be stopped anymore.
+// The deletion of the Runtime itself cannot be cancelled, the protected Runtime stays terminating until it is re-created for adoption
+func sFnCheckDeletion(_ context.Context, m *fsm, s *systemState) (stateFn, *ctrl.Result, error) {
+	m.log.Info("check deletion state")
+
+	if !s.shoot.GetDeletionTimestamp().IsZero() {
+		return switchState(sFnDeleteKubeconfig)
+	}
+
+	if s.instance.IsOrphanedOnDeletion() {
+		return switchState(sFnOrphanShoot)
+	}
+
+	// the GardenerCluster CR has already been deleted
+	if meta.FindStatusCondition(s.instance.Status.Conditions, string(imv1.ConditionTypeRuntimeDeprovisioned)) != nil {
+		return switchState(sFnDeleteKubeconfig)
+	}
+
+	if s.instance.IsDeletionProtected() {
+		m.log.Info("Runtime is protected against deletion, shoot will not be deleted", "Name", s.shoot.Name, "Namespace", s.shoot.Namespace)
+		meta.SetStatusCondition(&s.instance.Status.Conditions, metav1.Condition{
+			Type:    string(imv1.ConditionTypeDeletionScheduled),
+			Status:  metav1.ConditionFalse,
+			Reason:  string(imv1.ConditionReasonDeletionProtected),
+			Message: fmt.Sprintf("Shoot deletion cancelled, remove the %s annotation to delete the shoot, or remove the finalizer and create the Runtime again with the %s annotation to keep it", imv1.AnnotationDeletionProtection, imv1.AnnotationAdopt),
+		})
+		return updateStatusAndStop()
+	}
+
+	deletionTime := s.instance.GetDeletionTimestamp().Add(m.RCCfg.DeletionGracePeriod)
+	if remaining := time.Until(deletionTime); remaining > 0 {
+		m.log.Info("Shoot deletion scheduled", "Name", s.shoot.Name, "Namespace", s.shoot.Namespace, "deletionTime", deletionTime)
+		s.instance.UpdateStateDeletion(
+			imv1.ConditionTypeDeletionScheduled,
+			imv1.ConditionReasonDeletionScheduled,
+			"True",
+			fmt.Sprintf("Shoot deletion scheduled at %s, set the %s annotation to cancel it", deletionTime.UTC().Format(time.RFC3339), imv1.AnnotationDeletionProtection),
+		)
+		return updateStatusAndRequeueAfter(remaining)
+	}
+
+	return switchState(sFnDeleteKubeconfig)
+}
@@ -0,0 +1,128 @@
+package fsm
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	gardener "github.com/gardener/gardener/pkg/apis/core/v1beta1"
+	imv1 "github.com/kyma-project/infrastructure-manager/api/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestCheckDeletion(t *testing.T) {
+	newSystemState := func(deletedAgo time.Duration, annotations map[string]string) *systemState {
+		instance := runtimeForTest()
+		instance.Annotations = annotations
+		instance.DeletionTimestamp = &metav1.Time{Time: time.Now().Add(-deletedAgo)}
+
+		return &systemState{
+			instance: instance,
+			shoot:    &gardener.Shoot{ObjectMeta: metav1.ObjectMeta{Name: "test-shoot", Namespace: "garden-test"}},
+		}
+	}
+
+	t.Run("Should delete resources without grace period", func(t *testing.T) {
+		// given
+		systemState := newSystemState(0, nil)
+
+		fsm, err := newFakeFSM(withDeletionGracePeriod(0))
+		require.NoError(t, err)
+
+		// when
+		stateFn, _, err := sFnCheckDeletion(context.Background(), fsm, systemState)
+
+		// then
+		require.NoError(t, err)
+		assert.Contains(t, stateFn.name(), "sFnDeleteKubeconfig")
+	})
+
+	t.Run("Should schedule deletion within grace period", func(t *testing.T) {
+		// given
+		fsm, err := newFakeFSM(withDeletionGracePeriod(time.Hour))
+		require.NoError(t, err)
+		systemState := newSystemState(10*time.Minute, nil)
+
+		// when
+		stateFn, _, err := sFnCheckDeletion(context.Background(), fsm, systemState)
+
+		// then
+		require.NoError(t, err)
+		require.Contains(t, stateFn.name(), "sFnUpdateStatus")
+		result := runUpdateStatusResult(t, stateFn, fsm, systemState)
+		require.NotNil(t, result)
+		assert.InDelta(t, 50*time.Minute, result.RequeueAfter, float64(5*time.Second))
+		assert.Equal(t, imv1.State(imv1.RuntimeStateTerminating), systemState.instance.Status.State)
+		assert.True(t, systemState.instance.IsConditionSetWithStatus(imv1.ConditionTypeDeletionScheduled, imv1.ConditionReasonDeletionScheduled, metav1.ConditionTrue))
+	})
+
+	t.Run("Should delete resources after grace period", func(t *testing.T) {
+		// given
+		systemState := newSystemState(2*time.Hour, nil)
+
+		fsm, err := newFakeFSM(withDeletionGracePeriod(time.Hour))
+		require.NoError(t, err)
+
+		// when
+		stateFn, _, err := sFnCheckDeletion(context.Background(), fsm, systemState)
+
+		// then
+		require.NoError(t, err)
+		assert.Contains(t, stateFn.name(), "sFnDeleteKubeconfig")
+	})
+
+	t.Run("Should keep shoot of protected runtime", func(t *testing.T) {
+		// given
+		fsm, err := newFakeFSM(withDeletionGracePeriod(time.Hour))
+		require.NoError(t, err)
+		systemState := newSystemState(2*time.Hour, map[string]string{imv1.AnnotationDeletionProtection: "true"})
+
+		// when
+		stateFn, _, err := sFnCheckDeletion(context.Background(), fsm, systemState)
+
+		// then
+		require.NoError(t, err)
+		require.Contains(t, stateFn.name(), "sFnUpdateStatus")
+		assert.Nil(t, runUpdateStatusResult(t, stateFn, fsm, systemState))
+		assert.True(t, systemState.instance.IsConditionSetWithStatus(imv1.ConditionTypeDeletionScheduled, imv1.ConditionReasonDeletionProtected, metav1.ConditionFalse))
+	})
+
+	t.Run("Should orphan shoot regardless of protection", func(t *testing.T) {
+		// given
+		systemState := newSystemState(0, map[string]string{imv1.AnnotationDeletionProtection: "true"})
+		systemState.instance.Spec.Deletion = &imv1.Deletion{Policy: imv1.DeletionPolicyOrphan}
+
+		fsm, err := newFakeFSM(withDeletionGracePeriod(time.Hour))
+		require.NoError(t, err)
+
+		// when
+		stateFn, _, err := sFnCheckDeletion(context.Background(), fsm, systemState)
+
+		// then
+		require.NoError(t, err)
+		assert.Contains(t, stateFn.name(), "sFnOrphanShoot")
+	})
+
+	t.Run("Should continue deletion which has already started", func(t *testing.T) {
+		// given
+		systemState := newSystemState(0, map[string]string{imv1.AnnotationDeletionProtection: "true"})
+		meta.SetStatusCondition(&systemState.instance.Status.Conditions, metav1.Condition{
+			Type:   string(imv1.ConditionTypeRuntimeDeprovisioned),
+			Status: metav1.ConditionUnknown,
+			Reason: string(imv1.ConditionReasonGardenerCRDeleted),
+		})
+
+		fsm, err := newFakeFSM(withDeletionGracePeriod(time.Hour))
+		require.NoError(t, err)
+
+		// when
+		stateFn, _, err := sFnCheckDeletion(context.Background(), fsm, systemState)
+
+		// then
+		require.NoError(t, err)
+		assert.Contains(t, stateFn.name(), "sFnDeleteKubeconfig")
+	})
+}
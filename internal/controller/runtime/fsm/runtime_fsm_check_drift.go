@@ -0,0 +1,88 @@
+package fsm
+
+import (
+	"context"
+	"fmt"
+
+	imv1 "github.com/kyma-project/infrastructure-manager/api/v1"
+	"github.com/kyma-project/infrastructure-manager/pkg/gardener/shoot/drift"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+)
+
+// sFnCheckDrift compares the shoot which the patch of the Runtime would result in with the live shoot to detect the changes done directly in Gardener.
+// The differences are reported in the Drifted condition, and the shoot is patched if the Runtime requests the drift correction.
+// The versions updated by Gardener are not reported, both the check and the correction keep the versions running in the shoot.
+// The drift check does not block the processing of the Runtime, the next state is the one selected for the last shoot operation
+func sFnCheckDrift(ctx context.Context, m *fsm, s *systemState) (stateFn, *ctrl.Result, error) {
+	m.log.Info("Check drift state")
+
+	next := shootOperationState(s)
+
+	if err := loadCloudProfile(ctx, m, s); err != nil {
+		m.log.Error(err, "Failed to get cloud profile, skipping drift check")
+		return switchState(next)
+	}
+
+	desiredShoot, err := convertShoot(&s.instance, m.Config.ConverterConfig, s.cloudProfile)
+	if err != nil {
+		m.log.Error(err, "Failed to convert Runtime instance to shoot object, skipping drift check")
+		return switchState(next)
+	}
+
+	keepLiveVersions(&desiredShoot, s.shoot)
+
+	if err = keepImmutableSettings(&desiredShoot, s.shoot); err != nil {
+		m.log.Error(err, "Failed to keep immutable settings of the shoot, skipping drift check")
+		return switchState(next)
+	}
+
+	if err = dryRunPatchShoot(ctx, m, &desiredShoot); err != nil {
+		m.log.Error(err, "Failed to patch shoot object in dry-run mode, skipping drift check")
+		return switchState(next)
+	}
+
+	differences, err := drift.Compare(desiredShoot, *s.shoot)
+	if err != nil {
+		m.log.Error(err, "Failed to compare shoot with Runtime, skipping drift check")
+		return switchState(next)
+	}
+
+	if len(differences) == 0 {
+		setDriftedCondition(&s.instance, metav1.ConditionFalse, imv1.ConditionReasonNoDrift, "Shoot matches Runtime")
+		return switchState(next)
+	}
+
+	for _, difference := range differences {
+		m.log.Info("Shoot differs from Runtime", "Name", s.shoot.Name, "path", difference.Path, "desired", difference.Desired, "live", difference.Live)
+	}
+
+	if !s.instance.IsDriftCorrected() {
+		setDriftedCondition(&s.instance, metav1.ConditionTrue, imv1.ConditionReasonDriftDetected, fmt.Sprintf("Shoot differs from Runtime: %s", formatDifferences(differences)))
+		return switchState(next)
+	}
+
+	setDriftedCondition(&s.instance, metav1.ConditionTrue, imv1.ConditionReasonDriftCorrected, fmt.Sprintf("Shoot patched to match Runtime: %s", formatDifferences(differences)))
+	s.driftCorrection = true
+	return switchState(sFnPatchExistingShoot)
+}
+
+// setDriftedCondition does not change the state of the Runtime, the drift is reported for the Ready runtimes
+func setDriftedCondition(instance *imv1.Runtime, status metav1.ConditionStatus, reason imv1.RuntimeConditionReason, msg string) {
+	meta.SetStatusCondition(&instance.Status.Conditions, metav1.Condition{
+		Type:    string(imv1.ConditionTypeDrifted),
+		Status:  status,
+		Reason:  string(reason),
+		Message: msg,
+	})
+}
+
+// updateStatusAndScheduleDriftCheck stops the processing of the Ready runtime, the runtime is requeued for the next drift check if enabled
+func updateStatusAndScheduleDriftCheck(m *fsm) (stateFn, *ctrl.Result, error) {
+	if m.RCCfg.DriftCheckInterval > 0 {
+		return updateStatusAndRequeueAfter(m.RCCfg.DriftCheckInterval)
+	}
+
+	return updateStatusAndStop()
+}
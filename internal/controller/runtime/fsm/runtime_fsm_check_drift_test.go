@@ -0,0 +1,219 @@
+package fsm
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+	"time"
+
+	gardener "github.com/gardener/gardener/pkg/apis/core/v1beta1"
+	imv1 "github.com/kyma-project/infrastructure-manager/api/v1"
+	"github.com/kyma-project/infrastructure-manager/pkg/config"
+	"github.com/kyma-project/infrastructure-manager/pkg/gardener/shoot/extender"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+)
+
+func TestCheckDrift(t *testing.T) {
+	converterConfig := config.ConverterConfig{
+		Kubernetes: config.KubernetesConfig{DefaultVersion: "1.30"},
+		DNS: config.DNSConfig{
+			SecretName:   "dns-secret",
+			DomainPrefix: "dev.kyma.ondemand.com",
+			ProviderType: "aws-route53",
+		},
+		MachineImage: config.MachineImageConfig{DefaultName: "gardenlinux"},
+		Gardener:     config.GardenerConfig{ProjectName: "kyma-dev"},
+	}
+
+	cloudProfile := &gardener.CloudProfile{
+		ObjectMeta: metav1.ObjectMeta{Name: extender.DefaultAWSCloudProfileName},
+		Spec: gardener.CloudProfileSpec{
+			MachineImages: []gardener.MachineImage{
+				{
+					Name:     "gardenlinux",
+					Versions: []gardener.MachineImageVersion{{ExpirableVersion: gardener.ExpirableVersion{Version: "1592.1.0"}}},
+				},
+			},
+		},
+	}
+
+	fixRuntime := func(policy imv1.DriftPolicy) imv1.Runtime {
+		runtime := runtimeForTest()
+		runtime.Labels = map[string]string{
+			imv1.LabelKymaInstanceID:      "instance-id",
+			imv1.LabelKymaRuntimeID:       "runtime-id",
+			imv1.LabelKymaRegion:          "region",
+			imv1.LabelKymaName:            "kyma-name",
+			imv1.LabelKymaBrokerPlanID:    "plan-id",
+			imv1.LabelKymaBrokerPlanName:  "aws",
+			imv1.LabelKymaGlobalAccountID: "global-account-id",
+			imv1.LabelKymaSubaccountID:    "subaccount-id",
+		}
+		runtime.Spec.Drift = &imv1.Drift{Policy: policy}
+		runtime.Spec.Shoot.Kubernetes.Version = ptr.To("1.29")
+		runtime.Spec.Shoot.Networking = imv1.Networking{
+			Nodes:    "10.250.0.0/16",
+			Pods:     "100.64.0.0/12",
+			Services: "100.104.0.0/13",
+		}
+		runtime.Spec.Shoot.Provider.Workers = []gardener.Worker{
+			{
+				Name: "worker",
+				Machine: gardener.Machine{
+					Type:  "m6i.large",
+					Image: &gardener.ShootMachineImage{Name: "gardenlinux", Version: ptr.To("1592.1.0")},
+				},
+				Minimum: 1,
+				Maximum: 3,
+				Zones:   []string{"eu-central-1a"},
+			},
+		}
+		return runtime
+	}
+
+	fixLiveShoot := func(t *testing.T, runtime imv1.Runtime) *gardener.Shoot {
+		shoot, err := convertShoot(&runtime, converterConfig, cloudProfile)
+		require.NoError(t, err)
+		shoot.Status.LastOperation = &gardener.LastOperation{
+			Type:  gardener.LastOperationTypeReconcile,
+			State: gardener.LastOperationStateSucceeded,
+		}
+		return &shoot
+	}
+
+	scheme, err := newTestScheme()
+	require.NoError(t, err)
+	require.NoError(t, gardener.AddToScheme(scheme))
+
+	// the fake client does not support server-side apply, the dry-run returns the applied shoot and the applied shoots are recorded
+	recordApply := func(applied *[]gardener.Shoot) interceptor.Funcs {
+		return interceptor.Funcs{
+			Patch: func(_ context.Context, _ client.WithWatch, obj client.Object, patch client.Patch, opts ...client.PatchOption) error {
+				patchOptions := &client.PatchOptions{}
+				patchOptions.ApplyOptions(opts)
+				if patch != client.Apply {
+					return errors.New("expected server-side apply")
+				}
+				if !slices.Contains(patchOptions.DryRun, metav1.DryRunAll) && applied != nil {
+					*applied = append(*applied, *obj.(*gardener.Shoot).DeepCopy())
+				}
+				return nil
+			},
+		}
+	}
+
+	for tname, tcase := range map[string]struct {
+		policy            imv1.DriftPolicy
+		modifyShoot       func(shoot *gardener.Shoot)
+		expectedState     string
+		expectedStatus    metav1.ConditionStatus
+		expectedReason    imv1.RuntimeConditionReason
+		expectedInMessage string
+	}{
+		"Should report no drift for shoot matching Runtime": {
+			policy:         imv1.DriftPolicyReport,
+			modifyShoot:    func(_ *gardener.Shoot) {},
+			expectedState:  "sFnWaitForShootReconcile",
+			expectedStatus: metav1.ConditionFalse,
+			expectedReason: imv1.ConditionReasonNoDrift,
+		},
+		"Should ignore versions updated by Gardener": {
+			policy: imv1.DriftPolicyReport,
+			modifyShoot: func(shoot *gardener.Shoot) {
+				shoot.Spec.Kubernetes.Version = "1.29.8"
+				shoot.Spec.Provider.Workers[0].Machine.Image.Version = ptr.To("1592.2.0")
+			},
+			expectedState:  "sFnWaitForShootReconcile",
+			expectedStatus: metav1.ConditionFalse,
+			expectedReason: imv1.ConditionReasonNoDrift,
+		},
+		"Should report drift of shoot changed in Gardener": {
+			policy: imv1.DriftPolicyReport,
+			modifyShoot: func(shoot *gardener.Shoot) {
+				shoot.Spec.Provider.Workers[0].Maximum = 10
+			},
+			expectedState:     "sFnWaitForShootReconcile",
+			expectedStatus:    metav1.ConditionTrue,
+			expectedReason:    imv1.ConditionReasonDriftDetected,
+			expectedInMessage: "spec/provider/workers/worker/maximum: expected 3, got 10",
+		},
+		"Should patch shoot when Runtime requests drift correction": {
+			policy: imv1.DriftPolicyCorrect,
+			modifyShoot: func(shoot *gardener.Shoot) {
+				shoot.Spec.Provider.Workers[0].Maximum = 10
+			},
+			expectedState:     "sFnPatchExistingShoot",
+			expectedStatus:    metav1.ConditionTrue,
+			expectedReason:    imv1.ConditionReasonDriftCorrected,
+			expectedInMessage: "spec/provider/workers/worker/maximum: expected 3, got 10",
+		},
+	} {
+		t.Run(tname, func(t *testing.T) {
+			// given
+			runtime := fixRuntime(tcase.policy)
+			shoot := fixLiveShoot(t, *runtime.DeepCopy())
+			tcase.modifyShoot(shoot)
+			state := &systemState{instance: runtime, shoot: shoot}
+
+			fsm, err := newFakeFSM(withFakedK8sClientInterceptor(recordApply(nil), scheme, cloudProfile), withConverterConfig(converterConfig), withDriftCheckInterval(time.Hour))
+			require.NoError(t, err)
+
+			// when
+			stateFn, _, err := sFnCheckDrift(context.Background(), fsm, state)
+
+			// then
+			require.NoError(t, err)
+			require.Contains(t, stateFn.name(), tcase.expectedState)
+
+			condition := meta.FindStatusCondition(state.instance.Status.Conditions, string(imv1.ConditionTypeDrifted))
+			require.NotNil(t, condition)
+			assert.Equal(t, tcase.expectedStatus, condition.Status)
+			assert.Equal(t, string(tcase.expectedReason), condition.Reason)
+			assert.Contains(t, condition.Message, tcase.expectedInMessage)
+			assert.Equal(t, tcase.policy == imv1.DriftPolicyCorrect, state.driftCorrection)
+		})
+	}
+
+	t.Run("Should correct drift keeping versions running in shoot", func(t *testing.T) {
+		// given
+		runtime := fixRuntime(imv1.DriftPolicyCorrect)
+		shoot := fixLiveShoot(t, *runtime.DeepCopy())
+		shoot.Spec.Kubernetes.Version = "1.30.2"
+		shoot.Spec.Provider.Workers[0].Machine.Image.Version = ptr.To("1443.1.0")
+		shoot.Spec.Provider.Workers[0].Maximum = 10
+
+		var applied []gardener.Shoot
+		fsm, err := newFakeFSM(withFakedK8sClientInterceptor(recordApply(&applied), scheme, cloudProfile), withConverterConfig(converterConfig), withDriftCheckInterval(time.Hour))
+		require.NoError(t, err)
+		state := &systemState{instance: runtime, shoot: shoot}
+
+		// when
+		stateFn, _, err := sFnCheckDrift(context.Background(), fsm, state)
+
+		// then
+		require.NoError(t, err)
+		require.Contains(t, stateFn.name(), "sFnPatchExistingShoot")
+
+		condition := meta.FindStatusCondition(state.instance.Status.Conditions, string(imv1.ConditionTypeDrifted))
+		require.NotNil(t, condition)
+		assert.Equal(t, "Shoot patched to match Runtime: spec/provider/workers/worker/maximum: expected 3, got 10", condition.Message)
+
+		// when
+		stateFn, _, err = stateFn(context.Background(), fsm, state)
+
+		// then
+		require.NoError(t, err)
+		require.Contains(t, stateFn.name(), "sFnConfigureOidc")
+		require.Len(t, applied, 1)
+		assert.Equal(t, "1.30.2", applied[0].Spec.Kubernetes.Version)
+		assert.Equal(t, ptr.To("1443.1.0"), applied[0].Spec.Provider.Workers[0].Machine.Image.Version)
+		assert.Equal(t, int32(3), applied[0].Spec.Provider.Workers[0].Maximum)
+	})
+}
@@ -0,0 +1,29 @@
+package fsm
+
+import (
+	"context"
+
+	gardener "github.com/gardener/gardener/pkg/apis/core/v1beta1"
+	"github.com/kyma-project/infrastructure-manager/pkg/gardener/shoot/extender"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// loadCloudProfile reads the cloud profile used by the shoot of the Runtime, it is read only once per reconciliation
+func loadCloudProfile(ctx context.Context, m *fsm, s *systemState) error {
+	if s.cloudProfile != nil {
+		return nil
+	}
+
+	cloudProfileName, err := extender.GetCloudProfileName(s.instance, m.Config.ConverterConfig.CloudProfile)
+	if err != nil {
+		return err
+	}
+
+	var cloudProfile gardener.CloudProfile
+	if err := m.ShootClient.Get(ctx, client.ObjectKey{Name: cloudProfileName}, &cloudProfile); err != nil {
+		return err
+	}
+
+	s.cloudProfile = &cloudProfile
+	return nil
+}
@@ -35,7 +35,7 @@ func sFnConfigureAuditLog(ctx context.Context, m *fsm, s *systemState) (stateFn,
 			"Audit Log state completed successfully",
 		)
 
-		return updateStatusAndStop()
+		return updateStatusAndScheduleDriftCheck(m)
 	}
 
 	return handleError(err, m, s)
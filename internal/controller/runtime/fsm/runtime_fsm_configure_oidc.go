@@ -7,7 +7,6 @@ import (
 	gardener "github.com/gardener/gardener/pkg/apis/core/v1beta1"
 	authenticationv1alpha1 "github.com/gardener/oidc-webhook-authenticator/apis/authentication/v1alpha1"
 	imv1 "github.com/kyma-project/infrastructure-manager/api/v1"
-	"github.com/kyma-project/infrastructure-manager/pkg/config"
 	"github.com/kyma-project/infrastructure-manager/pkg/gardener/shoot/extender"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	ctrl "sigs.k8s.io/controller-runtime"
@@ -28,7 +27,7 @@ func sFnConfigureOidc(ctx context.Context, m *fsm, s *systemState) (stateFn, *ct
 		return switchState(sFnApplyClusterRoleBindings)
 	}
 
-	defaultAdditionalOidcIfNotPresent(&s.instance, m.RCCfg)
+	extender.DefaultAdditionalOidcIfNotPresent(&s.instance, m.ClusterConfig.DefaultSharedIASTenant)
 	err := recreateOpenIDConnectResources(ctx, m, s)
 
 	if err != nil {
@@ -48,28 +47,6 @@ func sFnConfigureOidc(ctx context.Context, m *fsm, s *systemState) (stateFn, *ct
 	return switchState(sFnApplyClusterRoleBindings)
 }
 
-func defaultAdditionalOidcIfNotPresent(runtime *imv1.Runtime, cfg RCCfg) {
-	additionalOidcConfig := runtime.Spec.Shoot.Kubernetes.KubeAPIServer.AdditionalOidcConfig
-
-	if additionalOidcConfig == nil {
-		additionalOidcConfig = &[]gardener.OIDCConfig{}
-		defaultOIDCConfig := createDefaultOIDCConfig(cfg.ClusterConfig.DefaultSharedIASTenant)
-		*additionalOidcConfig = append(*additionalOidcConfig, defaultOIDCConfig)
-		runtime.Spec.Shoot.Kubernetes.KubeAPIServer.AdditionalOidcConfig = additionalOidcConfig
-	}
-}
-
-func createDefaultOIDCConfig(defaultSharedIASTenant config.OidcProvider) gardener.OIDCConfig {
-	return gardener.OIDCConfig{
-		ClientID:       &defaultSharedIASTenant.ClientID,
-		GroupsClaim:    &defaultSharedIASTenant.GroupsClaim,
-		IssuerURL:      &defaultSharedIASTenant.IssuerURL,
-		SigningAlgs:    defaultSharedIASTenant.SigningAlgs,
-		UsernameClaim:  &defaultSharedIASTenant.UsernameClaim,
-		UsernamePrefix: &defaultSharedIASTenant.UsernamePrefix,
-	}
-}
-
 func recreateOpenIDConnectResources(ctx context.Context, m *fsm, s *systemState) error {
 	srscClient := m.ShootClient.SubResource("adminkubeconfig")
 	shootAdminClient, shootClientError := GetShootClient(ctx, srscClient, s.shoot)
@@ -56,6 +56,25 @@ func sFnCreateKubeconfig(ctx context.Context, m *fsm, s *systemState) (stateFn,
 		return updateStatusAndRequeueAfter(m.RCCfg.ControlPlaneRequeueDuration)
 	}
 
+	// the GardenerCluster CRs created before the shoot namespace was added are updated, the kubeconfig is fetched from the project of the shoot
+	if cluster.Spec.Shoot.Namespace != s.shoot.Namespace {
+		m.log.Info("Updating shoot namespace of GardenerCluster CR", "Name", runtimeID, "Namespace", s.shoot.Namespace)
+		cluster.Spec.Shoot.Namespace = s.shoot.Namespace
+		if err = m.Update(ctx, &cluster); err != nil {
+			m.log.Error(err, "GardenerCluster CR update error", "name", runtimeID)
+			s.instance.UpdateStatePending(
+				imv1.ConditionTypeRuntimeKubeconfigReady,
+				imv1.ConditionReasonKubernetesAPIErr,
+				"False",
+				err.Error(),
+			)
+			m.Metrics.IncRuntimeFSMStopCounter()
+			return updateStatusAndStop()
+		}
+
+		return requeueAfter(m.RCCfg.ControlPlaneRequeueDuration)
+	}
+
 	// wait section
 	if cluster.Status.State != imv1.ReadyState {
 		m.log.Info("GardenerCluster CR is not ready yet, requeue", "Name", runtimeID, "State", cluster.Status.State)
@@ -104,7 +123,8 @@ func makeGardenerClusterForRuntime(runtime imv1.Runtime, shoot *gardener.Shoot)
 		},
 		Spec: imv1.GardenerClusterSpec{
 			Shoot: imv1.Shoot{
-				Name: shoot.Name,
+				Name:      shoot.Name,
+				Namespace: shoot.Namespace,
 			},
 			Kubeconfig: imv1.Kubeconfig{
 				Secret: imv1.Secret{
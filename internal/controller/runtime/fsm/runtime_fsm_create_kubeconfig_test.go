@@ -60,6 +60,8 @@ var _ = Describe("KIM sFnCreateKubeconfig", func() {
 	// input
 	testGardenerCRStatePending := makeGardenerClusterCRStatePending()
 	testGardenerCRStateReady := makeGardenerClusterCRStateReady()
+	testGardenerCRWithoutShootNamespace := makeGardenerClusterCRStateReady()
+	testGardenerCRWithoutShootNamespace.Spec.Shoot.Namespace = ""
 
 	testShoot := gardener.Shoot{
 		ObjectMeta: metav1.ObjectMeta{
@@ -102,6 +104,16 @@ var _ = Describe("KIM sFnCreateKubeconfig", func() {
 				MatchNextFnState: BeNil(), // corresponds to requeueAfter(controlPlaneRequeueDuration)
 			},
 		),
+		Entry(
+			"should update shoot namespace when GardenCluster CR exists without it",
+			testCtx,
+			must(newFakeFSM, withTestFinalizer, withTestSchemeAndObjects(testGardenerCRWithoutShootNamespace), withMockedMetrics(), withDefaultReconcileDuration()),
+			&systemState{instance: *inputRtWithLabelsAndCondition, shoot: &testShoot},
+			testOpts{
+				MatchExpectedErr: BeNil(),
+				MatchNextFnState: BeNil(), // corresponds to requeueAfter(controlPlaneRequeueDuration)
+			},
+		),
 		Entry(
 			"should return sFnProcessShoot when GardenCluster CR exists and is in ready state",
 			testCtx,
@@ -158,7 +170,8 @@ func makeGardenerClusterCR() *imv1.GardenerCluster {
 		},
 		Spec: imv1.GardenerClusterSpec{
 			Shoot: imv1.Shoot{
-				Name: "test-instance",
+				Name:      "test-instance",
+				Namespace: "default",
 			},
 			Kubeconfig: imv1.Kubeconfig{
 				Secret: imv1.Secret{
@@ -11,7 +11,17 @@ import (
 func sFnCreateShoot(ctx context.Context, m *fsm, s *systemState) (stateFn, *ctrl.Result, error) {
 	m.log.Info("Create shoot state")
 
-	newShoot, err := convertShoot(&s.instance, m.Config.ConverterConfig)
+	if err := loadCloudProfile(ctx, m, s); err != nil {
+		m.log.Error(err, "Failed to get cloud profile")
+
+		return retryOrStop(m, s, isRetryableAPIError(err),
+			imv1.ConditionTypeRuntimeProvisioned,
+			imv1.ConditionReasonGardenerError,
+			fmt.Sprintf("Cloud profile read error: %v", err),
+		)
+	}
+
+	newShoot, err := convertShoot(&s.instance, m.Config.ConverterConfig, s.cloudProfile)
 	if err != nil {
 		m.log.Error(err, "Failed to convert Runtime instance to shoot object")
 		m.Metrics.IncRuntimeFSMStopCounter()
@@ -19,7 +29,7 @@ func sFnCreateShoot(ctx context.Context, m *fsm, s *systemState) (stateFn, *ctrl
 			&s.instance,
 			imv1.ConditionTypeRuntimeProvisioned,
 			imv1.ConditionReasonConversionError,
-			"Runtime conversion error")
+			fmt.Sprintf("Runtime conversion error: %v", err))
 	}
 
 	err = m.ShootClient.Create(ctx, &newShoot)
@@ -27,15 +37,16 @@ func sFnCreateShoot(ctx context.Context, m *fsm, s *systemState) (stateFn, *ctrl
 	if err != nil {
 		m.log.Error(err, "Failed to create new gardener Shoot")
 
-		s.instance.UpdateStatePending(
+		return retryOrStop(m, s, isRetryableAPIError(err),
 			imv1.ConditionTypeRuntimeProvisioned,
 			imv1.ConditionReasonGardenerError,
-			"False",
 			fmt.Sprintf("Gardener API create error: %v", err),
 		)
-		return updateStatusAndRequeueAfter(m.RCCfg.GardenerRequeueDuration)
 	}
 
+	resetRetries(s)
+	s.markGenerationApplied()
+
 	m.log.Info(
 		"Gardener shoot for runtime initialised successfully",
 		"Name", newShoot.Name,
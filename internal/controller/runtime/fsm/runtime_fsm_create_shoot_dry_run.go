@@ -10,7 +10,7 @@ import (
 func sFnCreateShootDryRun(_ context.Context, m *fsm, s *systemState) (stateFn, *ctrl.Result, error) {
 	m.log.Info("Create shoot [dry-run]")
 
-	newShoot, err := convertShoot(&s.instance, m.Config.ConverterConfig)
+	newShoot, err := convertShoot(&s.instance, m.Config.ConverterConfig, s.cloudProfile)
 	if err != nil {
 		m.log.Error(err, "Failed to convert Runtime instance to shoot object [dry-run]")
 		m.Metrics.IncRuntimeFSMStopCounter()
@@ -22,6 +22,7 @@ func sFnCreateShootDryRun(_ context.Context, m *fsm, s *systemState) (stateFn, *
 	}
 
 	s.shoot = &newShoot
+	s.markGenerationApplied()
 	s.instance.UpdateStateReady(
 		imv1.ConditionTypeRuntimeProvisionedDryRun,
 		imv1.ConditionReasonConfigurationCompleted,
@@ -0,0 +1,74 @@
+package fsm
+
+import (
+	"context"
+	"testing"
+
+	gardener "github.com/gardener/gardener/pkg/apis/core/v1beta1"
+	imv1 "github.com/kyma-project/infrastructure-manager/api/v1"
+	"github.com/kyma-project/infrastructure-manager/pkg/gardener/shoot/extender"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+)
+
+func TestSelectShootProcessingHibernation(t *testing.T) {
+	t.Run("Should set Hibernated state for hibernated shoot", func(t *testing.T) {
+		// given
+		ctx := context.Background()
+		fsm := &fsm{}
+
+		shootStub := fixHibernationShoot(true)
+		systemState := &systemState{
+			instance: runtimeForTest(),
+			shoot:    shootStub,
+		}
+
+		// when
+		stateFn, _, _ := sFnSelectShootProcessing(ctx, fsm, systemState)
+
+		// then
+		require.Contains(t, stateFn.name(), "sFnUpdateStatus")
+		assert.Equal(t, imv1.State(imv1.RuntimeStateHibernated), systemState.instance.Status.State)
+		assert.True(t, meta.IsStatusConditionTrue(systemState.instance.Status.Conditions, string(imv1.ConditionTypeRuntimeHibernated)))
+	})
+
+	t.Run("Should remove Hibernated condition and continue processing when shoot was woken up", func(t *testing.T) {
+		// given
+		ctx := context.Background()
+		fsm := &fsm{}
+
+		runtimeStub := runtimeForTest()
+		runtimeStub.UpdateStateHibernated(imv1.ConditionTypeRuntimeHibernated, imv1.ConditionReasonHibernated, "Shoot is hibernated")
+
+		systemState := &systemState{
+			instance: runtimeStub,
+			shoot:    fixHibernationShoot(false),
+		}
+
+		// when
+		stateFn, _, _ := sFnSelectShootProcessing(ctx, fsm, systemState)
+
+		// then
+		require.Contains(t, stateFn.name(), "sFnWaitForShootReconcile")
+		assert.Nil(t, meta.FindStatusCondition(systemState.instance.Status.Conditions, string(imv1.ConditionTypeRuntimeHibernated)))
+	})
+}
+
+func fixHibernationShoot(hibernated bool) *gardener.Shoot {
+	shoot := shootForTest()
+	shoot.Annotations = map[string]string{extender.ShootRuntimeGenerationAnnotation: "0"}
+	shoot.Spec.DNS = &gardener.DNS{Domain: ptr.To("test.kyma.example.com")}
+	shoot.Spec.Hibernation = &gardener.Hibernation{Enabled: ptr.To(hibernated)}
+	shoot.Status = gardener.ShootStatus{
+		IsHibernated: hibernated,
+		LastOperation: &gardener.LastOperation{
+			Type:           gardener.LastOperationTypeReconcile,
+			State:          gardener.LastOperationStateSucceeded,
+			LastUpdateTime: metav1.Now(),
+		},
+	}
+	return shoot
+}
@@ -43,6 +43,11 @@ func sFnInitialize(ctx context.Context, m *fsm, s *systemState) (stateFn, *ctrl.
 		return updateStatusAndRequeue()
 	}
 
+	if instanceIsNotBeingDeleted && !dryRunMode && s.instance.IsMarkedForAdoption() && (s.shoot == nil || !isShootAdopted(s.shoot, s.instance)) {
+		m.log.Info("Gardener shoot is marked for adoption")
+		return switchState(sFnAdoptShoot)
+	}
+
 	shootNeedsToBeCreated := func() bool {
 		if dryRunMode {
 			return instanceIsNotBeingDeleted && dryRunProvisioningCondition != nil &&
@@ -70,7 +75,7 @@ func sFnInitialize(ctx context.Context, m *fsm, s *systemState) (stateFn, *ctrl.
 	if !instanceIsNotBeingDeleted && instanceHasFinalizer {
 		if s.shoot != nil && !dryRunMode {
 			m.log.Info("Delete instance resources")
-			return switchState(sFnDeleteKubeconfig)
+			return switchState(sFnCheckDeletion)
 		}
 		return removeFinalizerAndStop(ctx, m, s) // resource cleanup completed
 	}
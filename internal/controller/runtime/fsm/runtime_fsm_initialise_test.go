@@ -183,7 +183,7 @@ var _ = Describe("KIM sFnInitialise", func() {
 			&systemState{instance: testRtWithDeletionTimestampAndFinalizer, shoot: &testShoot},
 			testOpts{
 				MatchExpectedErr: BeNil(),
-				MatchNextFnState: haveName("sFnDeleteKubeconfig"),
+				MatchNextFnState: haveName("sFnCheckDeletion"),
 			},
 		),
 		Entry(
@@ -0,0 +1,83 @@
+package fsm
+
+import (
+	"context"
+	"strings"
+
+	imv1 "github.com/kyma-project/infrastructure-manager/api/v1"
+	"github.com/kyma-project/infrastructure-manager/pkg/gardener/shoot/extender"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// sFnOrphanShoot releases the shoot of the Runtime deleted with the Orphan deletion policy,
+// the shoot and its infrastructure are kept and the finalizer of the Runtime is removed
+func sFnOrphanShoot(ctx context.Context, m *fsm, s *systemState) (stateFn, *ctrl.Result, error) {
+	m.log.Info("orphan shoot state")
+
+	if !s.instance.Spec.Deletion.KeepGardenerCluster {
+		runtimeID := s.instance.Labels[imv1.LabelKymaRuntimeID]
+		var cluster imv1.GardenerCluster
+		err := m.Get(ctx, types.NamespacedName{
+			Namespace: s.instance.Namespace,
+			Name:      runtimeID,
+		}, &cluster)
+
+		if err != nil && !k8serrors.IsNotFound(err) {
+			m.log.Error(err, "GardenerCluster CR read error", "name", runtimeID)
+			return requeue()
+		}
+
+		if err == nil {
+			// wait section
+			if !cluster.DeletionTimestamp.IsZero() {
+				m.log.Info("Waiting for GardenerCluster CR to be deleted", "Runtime", runtimeID, "Shoot", s.shoot.Name)
+				return requeueAfter(m.RCCfg.ControlPlaneRequeueDuration)
+			}
+
+			// action section
+			m.log.Info("deleting GardenerCluster CR", "Runtime", runtimeID, "Shoot", s.shoot.Name)
+			if err := m.Delete(ctx, &cluster); err != nil {
+				m.log.Error(err, "Failed to delete gardener Cluster CR")
+				return requeue()
+			}
+			return requeueAfter(m.RCCfg.ControlPlaneRequeueDuration)
+		}
+	}
+
+	if hasShootMetadata(s.shoot.Annotations) || hasShootMetadata(s.shoot.Labels) {
+		m.log.Info("removing infrastructure manager metadata from shoot", "Name", s.shoot.Name, "Namespace", s.shoot.Namespace)
+		original := s.shoot.DeepCopy()
+		s.shoot.Annotations = withoutShootMetadata(s.shoot.Annotations)
+		s.shoot.Labels = withoutShootMetadata(s.shoot.Labels)
+
+		if err := m.ShootClient.Patch(ctx, s.shoot, client.MergeFrom(original)); err != nil {
+			m.log.Error(err, "unable to patch shoot:", s.shoot.Name)
+			return requeue()
+		}
+	}
+
+	m.log.Info("shoot orphaned", "Name", s.shoot.Name, "Namespace", s.shoot.Namespace)
+	return removeFinalizerAndStop(ctx, m, s)
+}
+
+func hasShootMetadata(metadata map[string]string) bool {
+	for key := range metadata {
+		if strings.HasPrefix(key, extender.ShootMetadataPrefix) {
+			return true
+		}
+	}
+	return false
+}
+
+func withoutShootMetadata(metadata map[string]string) map[string]string {
+	result := make(map[string]string, len(metadata))
+	for key, value := range metadata {
+		if !strings.HasPrefix(key, extender.ShootMetadataPrefix) {
+			result[key] = value
+		}
+	}
+	return result
+}
@@ -0,0 +1,128 @@
+package fsm
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	gardener "github.com/gardener/gardener/pkg/apis/core/v1beta1"
+	imv1 "github.com/kyma-project/infrastructure-manager/api/v1"
+	"github.com/kyma-project/infrastructure-manager/internal/controller/metrics/mocks"
+	"github.com/kyma-project/infrastructure-manager/pkg/gardener/shoot/extender"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func TestOrphanShoot(t *testing.T) {
+	const finalizer = "test-finalizer"
+
+	newFixtures := func(keepGardenerCluster bool) (*imv1.Runtime, *gardener.Shoot, *imv1.GardenerCluster) {
+		runtime := runtimeForTest()
+		runtime.Labels = map[string]string{imv1.LabelKymaRuntimeID: "runtime-id"}
+		runtime.Finalizers = []string{finalizer}
+		runtime.DeletionTimestamp = &metav1.Time{Time: time.Now()}
+		runtime.Spec.Deletion = &imv1.Deletion{
+			Policy:              imv1.DeletionPolicyOrphan,
+			KeepGardenerCluster: keepGardenerCluster,
+		}
+
+		shoot := &gardener.Shoot{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "test-shoot",
+				Namespace: "garden-test",
+				Annotations: map[string]string{
+					extender.ShootRuntimeIDAnnotation:          "runtime-id",
+					extender.ShootRuntimeGenerationAnnotation:  "1",
+					extender.ShootRestrictedEUAccessAnnotation: "true",
+				},
+				Labels: map[string]string{
+					extender.ShootGlobalAccountLabel: "global-account-id",
+				},
+			},
+		}
+
+		cluster := &imv1.GardenerCluster{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "runtime-id",
+				Namespace: runtime.Namespace,
+			},
+		}
+
+		return &runtime, shoot, cluster
+	}
+
+	scheme, err := newTestScheme()
+	require.NoError(t, err)
+	require.NoError(t, gardener.AddToScheme(scheme))
+
+	metrics := &mocks.Metrics{}
+	metrics.On("CleanUpRuntimeGauge", mock.Anything).Return()
+
+	t.Run("Should delete GardenerCluster CR first", func(t *testing.T) {
+		// given
+		runtime, shoot, cluster := newFixtures(false)
+		fsm, err := newFakeFSM(withFakedK8sClient(scheme, runtime, shoot, cluster), withFinalizer(finalizer), withMetrics(metrics), withDefaultReconcileDuration())
+		require.NoError(t, err)
+		systemState := &systemState{instance: *runtime, shoot: shoot}
+
+		// when
+		stateFn, result, err := sFnOrphanShoot(context.Background(), fsm, systemState)
+
+		// then
+		require.NoError(t, err)
+		assert.Nil(t, stateFn)
+		require.NotNil(t, result)
+		assert.Equal(t, defaultControlPlaneRequeueDuration, result.RequeueAfter)
+
+		err = fsm.Get(context.Background(), client.ObjectKeyFromObject(cluster), &imv1.GardenerCluster{})
+		assert.True(t, k8serrors.IsNotFound(err))
+	})
+
+	t.Run("Should remove infrastructure manager metadata from shoot and finalizer from Runtime", func(t *testing.T) {
+		// given
+		runtime, shoot, _ := newFixtures(false)
+		fsm, err := newFakeFSM(withFakedK8sClient(scheme, runtime, shoot), withFinalizer(finalizer), withMetrics(metrics), withDefaultReconcileDuration())
+		require.NoError(t, err)
+		systemState := &systemState{instance: *runtime, shoot: shoot}
+
+		// when
+		stateFn, result, err := sFnOrphanShoot(context.Background(), fsm, systemState)
+
+		// then
+		require.NoError(t, err)
+		assert.Nil(t, stateFn)
+		assert.Nil(t, result)
+
+		var actualShoot gardener.Shoot
+		require.NoError(t, fsm.ShootClient.Get(context.Background(), client.ObjectKeyFromObject(shoot), &actualShoot))
+		assert.Equal(t, map[string]string{extender.ShootRestrictedEUAccessAnnotation: "true"}, actualShoot.Annotations)
+		assert.Equal(t, map[string]string{extender.ShootGlobalAccountLabel: "global-account-id"}, actualShoot.Labels)
+		assert.True(t, actualShoot.DeletionTimestamp.IsZero())
+
+		// the fake client removes the Runtime being deleted once its last finalizer is gone
+		err = fsm.Get(context.Background(), types.NamespacedName{Name: runtime.Name, Namespace: runtime.Namespace}, &imv1.Runtime{})
+		assert.True(t, k8serrors.IsNotFound(err))
+	})
+
+	t.Run("Should keep GardenerCluster CR", func(t *testing.T) {
+		// given
+		runtime, shoot, cluster := newFixtures(true)
+		fsm, err := newFakeFSM(withFakedK8sClient(scheme, runtime, shoot, cluster), withFinalizer(finalizer), withMetrics(metrics), withDefaultReconcileDuration())
+		require.NoError(t, err)
+		systemState := &systemState{instance: *runtime, shoot: shoot}
+
+		// when
+		stateFn, result, err := sFnOrphanShoot(context.Background(), fsm, systemState)
+
+		// then
+		require.NoError(t, err)
+		assert.Nil(t, stateFn)
+		assert.Nil(t, result)
+		assert.NoError(t, fsm.Get(context.Background(), client.ObjectKeyFromObject(cluster), &imv1.GardenerCluster{}))
+	})
+}
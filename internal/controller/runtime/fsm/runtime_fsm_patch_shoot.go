@@ -2,12 +2,18 @@ package fsm
 
 import (
 	"context"
+	"fmt"
 
 	gardener "github.com/gardener/gardener/pkg/apis/core/v1beta1"
 	imv1 "github.com/kyma-project/infrastructure-manager/api/v1"
 	"github.com/kyma-project/infrastructure-manager/pkg/config"
+	"github.com/kyma-project/infrastructure-manager/pkg/gardener/cloudprofile"
 	gardener_shoot "github.com/kyma-project/infrastructure-manager/pkg/gardener/shoot"
+	"github.com/kyma-project/infrastructure-manager/pkg/gardener/shoot/hyperscaler"
+	"github.com/kyma-project/infrastructure-manager/pkg/gardener/shoot/hyperscaler/openstack"
 	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/utils/ptr"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -16,11 +22,28 @@ import (
 func sFnPatchExistingShoot(ctx context.Context, m *fsm, s *systemState) (stateFn, *ctrl.Result, error) {
 	m.log.Info("Patch shoot state")
 
-	updatedShoot, err := convertShoot(&s.instance, m.Config.ConverterConfig)
+	if err := loadCloudProfile(ctx, m, s); err != nil {
+		m.log.Error(err, "Failed to get cloud profile")
+		return retryOrStop(m, s, isRetryableAPIError(err), imv1.ConditionTypeRuntimeProvisioned, imv1.ConditionReasonGardenerError, fmt.Sprintf("Cloud profile read error: %v", err))
+	}
+
+	updatedShoot, err := convertShoot(&s.instance, m.Config.ConverterConfig, s.cloudProfile)
 	if err != nil {
 		m.log.Error(err, "Failed to convert Runtime instance to shoot object, exiting with no retry")
 		m.Metrics.IncRuntimeFSMStopCounter()
-		return updateStatePendingWithErrorAndStop(&s.instance, imv1.ConditionTypeRuntimeProvisioned, imv1.ConditionReasonConversionError, "Runtime conversion error")
+		return updateStatePendingWithErrorAndStop(&s.instance, imv1.ConditionTypeRuntimeProvisioned, imv1.ConditionReasonConversionError, fmt.Sprintf("Runtime conversion error: %v", err))
+	}
+
+	keepMaintainedVersions(&updatedShoot, s.shoot, s.instance)
+
+	if s.driftCorrection {
+		keepLiveVersions(&updatedShoot, s.shoot)
+	}
+
+	if err = keepImmutableSettings(&updatedShoot, s.shoot); err != nil {
+		m.log.Error(err, "Failed to keep immutable settings of the shoot, exiting with no retry")
+		m.Metrics.IncRuntimeFSMStopCounter()
+		return updateStatePendingWithErrorAndStop(&s.instance, imv1.ConditionTypeRuntimeProvisioned, imv1.ConditionReasonConversionError, fmt.Sprintf("Runtime conversion error: %v", err))
 	}
 
 	m.log.Info("Shoot converted successfully", "Name", updatedShoot.Name, "Namespace", updatedShoot.Namespace)
@@ -33,14 +56,16 @@ func sFnPatchExistingShoot(ctx context.Context, m *fsm, s *systemState) (stateFn
 	if err != nil {
 		if k8serrors.IsConflict(err) {
 			m.log.Info("Gardener shoot for runtime is outdated, retrying", "Name", s.shoot.Name, "Namespace", s.shoot.Namespace)
-			return updateStatusAndRequeueAfter(m.RCCfg.GardenerRequeueDuration)
+		} else {
+			m.log.Error(err, "Failed to patch shoot object")
 		}
 
-		m.log.Error(err, "Failed to patch shoot object, exiting with no retry")
-		m.Metrics.IncRuntimeFSMStopCounter()
-		return updateStatePendingWithErrorAndStop(&s.instance, imv1.ConditionTypeRuntimeProvisioned, imv1.ConditionReasonProcessingErr, "Shoot patch error")
+		return retryOrStop(m, s, isRetryableAPIError(err), imv1.ConditionTypeRuntimeProvisioned, imv1.ConditionReasonProcessingErr, fmt.Sprintf("Shoot patch error: %v", err))
 	}
 
+	resetRetries(s)
+	s.markGenerationApplied()
+
 	if updatedShoot.Generation == s.shoot.Generation {
 		m.log.Info("Gardener shoot for runtime did not change after patch, moving to processing", "Name", s.shoot.Name, "Namespace", s.shoot.Namespace)
 		return switchState(sFnConfigureOidc)
@@ -58,12 +83,17 @@ func sFnPatchExistingShoot(ctx context.Context, m *fsm, s *systemState) (stateFn
 	return updateStatusAndRequeueAfter(m.RCCfg.GardenerRequeueDuration)
 }
 
-func convertShoot(instance *imv1.Runtime, cfg config.ConverterConfig) (gardener.Shoot, error) {
+// convertShoot converts the Runtime to the shoot, the machine image versions are resolved from the cloud profile if it is given
+func convertShoot(instance *imv1.Runtime, cfg config.ConverterConfig, cloudProfile *gardener.CloudProfile) (gardener.Shoot, error) {
 	if err := instance.ValidateRequiredLabels(); err != nil {
 		return gardener.Shoot{}, err
 	}
 
 	converter := gardener_shoot.NewConverter(cfg)
+	if cloudProfile != nil {
+		converter = gardener_shoot.NewConverterWithCloudProfile(cfg, *cloudProfile)
+	}
+
 	newShoot, err := converter.ToShoot(*instance)
 
 	if err == nil {
@@ -73,6 +103,95 @@ func convertShoot(instance *imv1.Runtime, cfg config.ConverterConfig) (gardener.
 	return newShoot, err
 }
 
+// keepMaintainedVersions keeps the versions Gardener updated during the maintenance, patching them back would be a downgrade.
+// The machine image versions of the existing workers are kept also if the Runtime does not request any version,
+// the latest version from the cloud profile is resolved only for the new workers to avoid the rollout of the nodes
+func keepMaintainedVersions(desired *gardener.Shoot, live *gardener.Shoot, instance imv1.Runtime) {
+	if cloudprofile.IsKubernetesPatchUpdated(live.Spec.Kubernetes.Version, desired.Spec.Kubernetes.Version) {
+		desired.Spec.Kubernetes.Version = live.Spec.Kubernetes.Version
+	}
+
+	for i := 0; i < len(desired.Spec.Provider.Workers); i++ {
+		worker := &desired.Spec.Provider.Workers[i]
+		liveImage := findWorkerMachineImage(live.Spec.Provider.Workers, worker.Name)
+		if worker.Machine.Image == nil || liveImage == nil || liveImage.Version == nil || liveImage.Name != worker.Machine.Image.Name {
+			continue
+		}
+
+		requestedImage := findWorkerMachineImage(instance.Spec.Shoot.Provider.Workers, worker.Name)
+		isVersionRequested := requestedImage != nil && requestedImage.Version != nil && *requestedImage.Version != ""
+		if isVersionRequested && !cloudprofile.IsMachineImageVersionNewer(*liveImage.Version, *requestedImage.Version) {
+			continue
+		}
+
+		worker.Machine.Image = worker.Machine.Image.DeepCopy()
+		worker.Machine.Image.Version = ptr.To(*liveImage.Version)
+	}
+}
+
+// keepLiveVersions keeps all the kubernetes and machine image versions of the existing shoot,
+// the drift correction neither upgrades nor downgrades the shoot, the versions are changed only by the Runtime updates
+func keepLiveVersions(desired *gardener.Shoot, live *gardener.Shoot) {
+	desired.Spec.Kubernetes.Version = live.Spec.Kubernetes.Version
+
+	for i := 0; i < len(desired.Spec.Provider.Workers); i++ {
+		worker := &desired.Spec.Provider.Workers[i]
+		liveImage := findWorkerMachineImage(live.Spec.Provider.Workers, worker.Name)
+		if worker.Machine.Image == nil || liveImage == nil || liveImage.Name != worker.Machine.Image.Name {
+			continue
+		}
+
+		worker.Machine.Image = worker.Machine.Image.DeepCopy()
+		worker.Machine.Image.Version = nil
+		if liveImage.Version != nil {
+			worker.Machine.Image.Version = ptr.To(*liveImage.Version)
+		}
+	}
+}
+
+// keepImmutableSettings keeps the settings of the existing shoot which cannot be changed in Gardener,
+// the settings configured for the region may change after the shoot was created
+func keepImmutableSettings(desired *gardener.Shoot, live *gardener.Shoot) error {
+	if desired.Spec.Provider.Type != hyperscaler.TypeOpenStack {
+		return nil
+	}
+
+	desired.Spec.ExposureClassName = live.Spec.ExposureClassName
+
+	if desired.Spec.Provider.InfrastructureConfig == nil || live.Spec.Provider.InfrastructureConfig == nil {
+		return nil
+	}
+
+	infrastructureConfig, err := openstack.KeepFloatingPoolName(desired.Spec.Provider.InfrastructureConfig.Raw, live.Spec.Provider.InfrastructureConfig.Raw)
+	if err != nil {
+		return err
+	}
+
+	desired.Spec.Provider.InfrastructureConfig = &runtime.RawExtension{Raw: infrastructureConfig}
+
+	return nil
+}
+
+func findWorkerMachineImage(workers []gardener.Worker, name string) *gardener.ShootMachineImage {
+	for _, worker := range workers {
+		if worker.Name == name {
+			return worker.Machine.Image
+		}
+	}
+
+	return nil
+}
+
+// dryRunPatchShoot applies the shoot in the dry-run mode, the shoot is replaced with the result accepted by Gardener,
+// which contains also the defaults and the fields managed by the others
+func dryRunPatchShoot(ctx context.Context, m *fsm, shoot *gardener.Shoot) error {
+	return m.ShootClient.Patch(ctx, shoot, client.Apply, &client.PatchOptions{
+		FieldManager: "kim",
+		Force:        ptr.To(true),
+		DryRun:       []string{metav1.DryRunAll},
+	})
+}
+
 // workaround
 func setObjectFields(shoot *gardener.Shoot) {
 	shoot.Kind = "Shoot"
@@ -0,0 +1,149 @@
+package fsm
+
+import (
+	"testing"
+
+	gardener "github.com/gardener/gardener/pkg/apis/core/v1beta1"
+	imv1 "github.com/kyma-project/infrastructure-manager/api/v1"
+	"github.com/kyma-project/infrastructure-manager/pkg/gardener/shoot/hyperscaler"
+	"github.com/kyma-project/infrastructure-manager/pkg/gardener/shoot/hyperscaler/openstack"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/utils/ptr"
+)
+
+func TestKeepMaintainedVersions(t *testing.T) {
+	for tname, tc := range map[string]struct {
+		desiredVersion  string
+		liveVersion     string
+		expectedVersion string
+	}{
+		"Should keep patch updated during maintenance":   {desiredVersion: "1.29.5", liveVersion: "1.29.8", expectedVersion: "1.29.8"},
+		"Should apply requested patch upgrade":           {desiredVersion: "1.29.8", liveVersion: "1.29.5", expectedVersion: "1.29.8"},
+		"Should apply requested minor upgrade":           {desiredVersion: "1.30.1", liveVersion: "1.29.8", expectedVersion: "1.30.1"},
+		"Should apply version requested without patch":   {desiredVersion: "1.29", liveVersion: "1.29.8", expectedVersion: "1.29"},
+		"Should not hide downgrade of the minor version": {desiredVersion: "1.28.10", liveVersion: "1.29.8", expectedVersion: "1.28.10"},
+	} {
+		t.Run(tname, func(t *testing.T) {
+			// given
+			desired := gardener.Shoot{Spec: gardener.ShootSpec{Kubernetes: gardener.Kubernetes{Version: tc.desiredVersion}}}
+			live := gardener.Shoot{Spec: gardener.ShootSpec{Kubernetes: gardener.Kubernetes{Version: tc.liveVersion}}}
+
+			// when
+			keepMaintainedVersions(&desired, &live, imv1.Runtime{})
+
+			// then
+			assert.Equal(t, tc.expectedVersion, desired.Spec.Kubernetes.Version)
+		})
+	}
+}
+
+func TestKeepMaintainedMachineImageVersions(t *testing.T) {
+	fixWorkers := func(imageName string, imageVersion *string) []gardener.Worker {
+		return []gardener.Worker{
+			{
+				Name:    "worker",
+				Machine: gardener.Machine{Type: "m6i.large", Image: &gardener.ShootMachineImage{Name: imageName, Version: imageVersion}},
+			},
+		}
+	}
+
+	for tname, tc := range map[string]struct {
+		requestedVersion *string
+		desiredImageName string
+		desiredVersion   string
+		liveWorkers      []gardener.Worker
+		expectedVersion  string
+	}{
+		"Should keep live version of existing worker if Runtime does not request version": {
+			desiredImageName: "gardenlinux",
+			desiredVersion:   "1592.2.0",
+			liveWorkers:      fixWorkers("gardenlinux", ptr.To("1592.1.0")),
+			expectedVersion:  "1592.1.0",
+		},
+		"Should keep version updated during maintenance": {
+			requestedVersion: ptr.To("1592.1.0"),
+			desiredImageName: "gardenlinux",
+			desiredVersion:   "1592.1.0",
+			liveWorkers:      fixWorkers("gardenlinux", ptr.To("1592.2.0")),
+			expectedVersion:  "1592.2.0",
+		},
+		"Should apply requested version upgrade": {
+			requestedVersion: ptr.To("1592.2.0"),
+			desiredImageName: "gardenlinux",
+			desiredVersion:   "1592.2.0",
+			liveWorkers:      fixWorkers("gardenlinux", ptr.To("1592.1.0")),
+			expectedVersion:  "1592.2.0",
+		},
+		"Should apply resolved version for new worker": {
+			desiredImageName: "gardenlinux",
+			desiredVersion:   "1592.2.0",
+			expectedVersion:  "1592.2.0",
+		},
+		"Should apply resolved version for changed machine image": {
+			desiredImageName: "ubuntu",
+			desiredVersion:   "22.4.0",
+			liveWorkers:      fixWorkers("gardenlinux", ptr.To("1592.1.0")),
+			expectedVersion:  "22.4.0",
+		},
+	} {
+		t.Run(tname, func(t *testing.T) {
+			// given
+			runtime := imv1.Runtime{}
+			runtime.Spec.Shoot.Provider.Workers = fixWorkers(tc.desiredImageName, tc.requestedVersion)
+			desired := gardener.Shoot{}
+			desired.Spec.Provider.Workers = fixWorkers(tc.desiredImageName, ptr.To(tc.desiredVersion))
+			live := gardener.Shoot{}
+			live.Spec.Provider.Workers = tc.liveWorkers
+
+			// when
+			keepMaintainedVersions(&desired, &live, runtime)
+
+			// then
+			assert.Equal(t, tc.expectedVersion, *desired.Spec.Provider.Workers[0].Machine.Image.Version)
+			assert.Equal(t, tc.requestedVersion, runtime.Spec.Shoot.Provider.Workers[0].Machine.Image.Version)
+		})
+	}
+}
+
+func TestKeepImmutableSettings(t *testing.T) {
+	fixShoot := func(t *testing.T, providerType, exposureClassName, floatingPoolName string) gardener.Shoot {
+		infrastructureConfig, err := openstack.GetInfrastructureConfigWithOptions("10.250.0.0/22", nil, openstack.Options{FloatingPoolName: floatingPoolName})
+		require.NoError(t, err)
+
+		shoot := gardener.Shoot{}
+		shoot.Spec.Provider.Type = providerType
+		shoot.Spec.Provider.InfrastructureConfig = &runtime.RawExtension{Raw: infrastructureConfig}
+		shoot.Spec.ExposureClassName = ptr.To(exposureClassName)
+		return shoot
+	}
+
+	t.Run("Should keep exposure class and floating pool of existing OpenStack shoot", func(t *testing.T) {
+		// given
+		desired := fixShoot(t, hyperscaler.TypeOpenStack, "converged-cloud-internal", "FloatingIP-external-kyma-02")
+		live := fixShoot(t, hyperscaler.TypeOpenStack, "converged-cloud-internet", "FloatingIP-external-kyma-01")
+
+		// when
+		err := keepImmutableSettings(&desired, &live)
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, ptr.To("converged-cloud-internet"), desired.Spec.ExposureClassName)
+		assert.JSONEq(t, string(live.Spec.Provider.InfrastructureConfig.Raw), string(desired.Spec.Provider.InfrastructureConfig.Raw))
+	})
+
+	t.Run("Should not change shoots of other providers", func(t *testing.T) {
+		// given
+		desired := fixShoot(t, hyperscaler.TypeAWS, "internal", "pool-2")
+		live := fixShoot(t, hyperscaler.TypeAWS, "internet", "pool-1")
+
+		// when
+		err := keepImmutableSettings(&desired, &live)
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, ptr.To("internal"), desired.Spec.ExposureClassName)
+		assert.Contains(t, string(desired.Spec.Provider.InfrastructureConfig.Raw), "pool-2")
+	})
+}
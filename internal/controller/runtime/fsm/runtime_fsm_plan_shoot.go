@@ -0,0 +1,173 @@
+package fsm
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	imv1 "github.com/kyma-project/infrastructure-manager/api/v1"
+	"github.com/kyma-project/infrastructure-manager/pkg/gardener/cloudprofile"
+	"github.com/kyma-project/infrastructure-manager/pkg/gardener/shoot/drift"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/version"
+	ctrl "sigs.k8s.io/controller-runtime"
+)
+
+// sFnPlanShootPatch computes the changes of the shoot which sFnPatchExistingShoot would apply, without applying them.
+// The shoot is patched with a server-side apply dry-run, so the plan contains the changes accepted by Gardener
+// including the defaults, and the result is compared with the live shoot in both directions, so the worker pools,
+// extensions and tolerations applied by KIM and removed from the Runtime are reported too
+func sFnPlanShootPatch(ctx context.Context, m *fsm, s *systemState) (stateFn, *ctrl.Result, error) {
+	m.log.Info("Plan shoot patch state")
+
+	if err := loadCloudProfile(ctx, m, s); err != nil {
+		m.log.Error(err, "Failed to get cloud profile")
+		return planFailed(m, s, isRetryableAPIError(err), fmt.Sprintf("Cloud profile read error: %v", err))
+	}
+
+	// the same validation as in sFnValidateKubernetesUpgrade, the rejected upgrade stops the patch
+	if isKubernetesVersionChangeRequested(m, s) {
+		err := cloudprofile.ValidateKubernetesUpgrade(*s.cloudProfile, s.shoot.Spec.Kubernetes.Version, requestedKubernetesVersion(m, s), time.Now())
+		if err != nil {
+			m.log.Info("Kubernetes upgrade rejected, shoot patch would not be applied", "reason", err.Error())
+			return planRejected(s, fmt.Sprintf("Kubernetes upgrade rejected: %v", err))
+		}
+	}
+
+	plannedShoot, err := convertShoot(&s.instance, m.Config.ConverterConfig, s.cloudProfile)
+	if err != nil {
+		m.log.Error(err, "Failed to convert Runtime instance to shoot object")
+		return planFailed(m, s, false, fmt.Sprintf("Runtime conversion error: %v", err))
+	}
+
+	keepMaintainedVersions(&plannedShoot, s.shoot, s.instance)
+
+	if err = keepImmutableSettings(&plannedShoot, s.shoot); err != nil {
+		m.log.Error(err, "Failed to keep immutable settings of the shoot")
+		return planFailed(m, s, false, fmt.Sprintf("Runtime conversion error: %v", err))
+	}
+
+	if err = dryRunPatchShoot(ctx, m, &plannedShoot); err != nil {
+		m.log.Error(err, "Failed to patch shoot object in dry-run mode")
+		return planFailed(m, s, isRetryableAPIError(err), fmt.Sprintf("Shoot dry-run patch error: %v", err))
+	}
+
+	differences, err := drift.Compare(plannedShoot, *s.shoot)
+	if err != nil {
+		m.log.Error(err, "Failed to compare planned shoot with the existing one")
+		return planFailed(m, s, false, fmt.Sprintf("Shoot comparison error: %v", err))
+	}
+
+	removed, err := drift.Removed(plannedShoot, *s.shoot, "kim")
+	if err != nil {
+		m.log.Error(err, "Failed to compare planned shoot with the existing one")
+		return planFailed(m, s, false, fmt.Sprintf("Shoot comparison error: %v", err))
+	}
+	differences = append(differences, removed...)
+
+	plan := &imv1.PlanStatus{
+		ObservedGeneration: s.instance.Generation,
+		NodesRollout:       isNodesRolloutRequired(differences),
+	}
+	for _, difference := range differences {
+		plan.Changes = append(plan.Changes, imv1.PlanChange{
+			Path:    difference.Path,
+			Current: difference.Live,
+			Planned: difference.Desired,
+		})
+	}
+
+	m.log.Info("Shoot patch planned", "Name", s.shoot.Name, "changes", len(plan.Changes), "nodesRollout", plan.NodesRollout)
+
+	s.instance.Status.Plan = plan
+	setPlannedCondition(&s.instance, metav1.ConditionTrue, imv1.ConditionReasonPlanCompleted, planMessage(plan))
+
+	return updateStatusAndStop()
+}
+
+// planFailed does not change the state of the Runtime, the running shoot is not affected by the failed plan
+func planFailed(m *fsm, s *systemState, retryable bool, msg string) (stateFn, *ctrl.Result, error) {
+	s.instance.Status.Plan = nil
+	setPlannedCondition(&s.instance, metav1.ConditionFalse, imv1.ConditionReasonPlanErr, msg)
+
+	if retryable {
+		return updateStatusAndRequeueAfter(m.RCCfg.GardenerRequeueDuration)
+	}
+
+	return updateStatusAndStop()
+}
+
+// planRejected reports the patch which would be rejected before it is sent to Gardener, so the plan has no changes
+func planRejected(s *systemState, msg string) (stateFn, *ctrl.Result, error) {
+	s.instance.Status.Plan = &imv1.PlanStatus{
+		ObservedGeneration: s.instance.Generation,
+		Rejected:           msg,
+	}
+	setPlannedCondition(&s.instance, metav1.ConditionFalse, imv1.ConditionReasonPlanRejected, msg)
+
+	return updateStatusAndStop()
+}
+
+func planMessage(plan *imv1.PlanStatus) string {
+	if len(plan.Changes) == 0 {
+		return "Shoot patch planned, no changes"
+	}
+
+	msg := fmt.Sprintf("Shoot patch planned, %d changes", len(plan.Changes))
+	if plan.NodesRollout {
+		msg += ", worker nodes will be rolled out"
+	}
+
+	return msg
+}
+
+// isNodesRolloutRequired returns true if one of the changes replaces the machines of the existing worker pools,
+// i.e. the machine type, the machine image, the volume, the worker provider config, or the kubernetes minor version
+func isNodesRolloutRequired(differences []drift.Difference) bool {
+	for _, difference := range differences {
+		if strings.HasPrefix(difference.Path, "spec/provider/workers/") {
+			for _, suffix := range []string{"/machine/type", "/machine/image", "/machine/image/name", "/machine/image/version", "/volume", "/providerConfig"} {
+				if strings.HasSuffix(difference.Path, suffix) {
+					return true
+				}
+			}
+		}
+
+		if difference.Path == "spec/kubernetes/version" && isMinorVersionChanged(difference.Live, difference.Desired) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func isMinorVersionChanged(currentVersion, plannedVersion string) bool {
+	current, err := version.ParseGeneric(currentVersion)
+	if err != nil {
+		return true
+	}
+
+	planned, err := version.ParseGeneric(plannedVersion)
+	if err != nil {
+		return true
+	}
+
+	return current.Major() != planned.Major() || current.Minor() != planned.Minor()
+}
+
+func setPlannedCondition(instance *imv1.Runtime, status metav1.ConditionStatus, reason imv1.RuntimeConditionReason, msg string) {
+	meta.SetStatusCondition(&instance.Status.Conditions, metav1.Condition{
+		Type:    string(imv1.ConditionTypePlanned),
+		Status:  status,
+		Reason:  string(reason),
+		Message: msg,
+	})
+}
+
+// clearPlan removes the plan of the Runtime which is no longer marked with the plan annotation
+func clearPlan(s *systemState) {
+	s.instance.Status.Plan = nil
+	meta.RemoveStatusCondition(&s.instance.Status.Conditions, string(imv1.ConditionTypePlanned))
+}
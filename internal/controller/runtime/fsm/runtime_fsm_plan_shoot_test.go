@@ -0,0 +1,299 @@
+package fsm
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	gardener "github.com/gardener/gardener/pkg/apis/core/v1beta1"
+	imv1 "github.com/kyma-project/infrastructure-manager/api/v1"
+	"github.com/kyma-project/infrastructure-manager/pkg/config"
+	"github.com/kyma-project/infrastructure-manager/pkg/gardener/shoot/extender"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+)
+
+func TestPlanShootPatch(t *testing.T) {
+	converterConfig := config.ConverterConfig{
+		Kubernetes: config.KubernetesConfig{DefaultVersion: "1.30"},
+		DNS: config.DNSConfig{
+			SecretName:   "dns-secret",
+			DomainPrefix: "dev.kyma.ondemand.com",
+			ProviderType: "aws-route53",
+		},
+		MachineImage: config.MachineImageConfig{DefaultName: "gardenlinux"},
+		Gardener:     config.GardenerConfig{ProjectName: "kyma-dev"},
+	}
+
+	cloudProfile := &gardener.CloudProfile{
+		ObjectMeta: metav1.ObjectMeta{Name: extender.DefaultAWSCloudProfileName},
+		Spec: gardener.CloudProfileSpec{
+			Kubernetes: gardener.KubernetesSettings{
+				Versions: []gardener.ExpirableVersion{
+					{Version: "1.29.8"},
+					{Version: "1.30.4"},
+					{Version: "1.31.1", ExpirationDate: &metav1.Time{Time: time.Now().Add(-time.Hour)}},
+				},
+			},
+			MachineImages: []gardener.MachineImage{
+				{
+					Name:     "gardenlinux",
+					Versions: []gardener.MachineImageVersion{{ExpirableVersion: gardener.ExpirableVersion{Version: "1592.1.0"}}},
+				},
+			},
+		},
+	}
+
+	fixRuntime := func() imv1.Runtime {
+		runtime := runtimeForTest()
+		runtime.Labels = map[string]string{
+			imv1.LabelKymaInstanceID:      "instance-id",
+			imv1.LabelKymaRuntimeID:       "runtime-id",
+			imv1.LabelKymaRegion:          "region",
+			imv1.LabelKymaName:            "kyma-name",
+			imv1.LabelKymaBrokerPlanID:    "plan-id",
+			imv1.LabelKymaBrokerPlanName:  "aws",
+			imv1.LabelKymaGlobalAccountID: "global-account-id",
+			imv1.LabelKymaSubaccountID:    "subaccount-id",
+		}
+		runtime.Annotations = map[string]string{imv1.AnnotationPlan: "true"}
+		runtime.Generation = 2
+		runtime.Spec.Shoot.Kubernetes.Version = ptr.To("1.29")
+		runtime.Spec.Shoot.Networking = imv1.Networking{
+			Nodes:    "10.250.0.0/16",
+			Pods:     "100.64.0.0/12",
+			Services: "100.104.0.0/13",
+		}
+		runtime.Spec.Shoot.Provider.Workers = []gardener.Worker{
+			{
+				Name: "worker",
+				Machine: gardener.Machine{
+					Type:  "m6i.large",
+					Image: &gardener.ShootMachineImage{Name: "gardenlinux", Version: ptr.To("1592.1.0")},
+				},
+				Minimum: 1,
+				Maximum: 3,
+				Zones:   []string{"eu-central-1a"},
+			},
+		}
+		return runtime
+	}
+
+	fixLiveShoot := func(t *testing.T, runtime imv1.Runtime) *gardener.Shoot {
+		shoot, err := convertShoot(&runtime, converterConfig, cloudProfile)
+		require.NoError(t, err)
+		return &shoot
+	}
+
+	scheme, err := newTestScheme()
+	require.NoError(t, err)
+	require.NoError(t, gardener.AddToScheme(scheme))
+
+	// the fake client does not support server-side apply, the dry-run returns the applied shoot
+	dryRunPatch := func(patchErr error) interceptor.Funcs {
+		return interceptor.Funcs{
+			Patch: func(_ context.Context, _ client.WithWatch, _ client.Object, patch client.Patch, opts ...client.PatchOption) error {
+				patchOptions := &client.PatchOptions{}
+				patchOptions.ApplyOptions(opts)
+				if patch != client.Apply || !slices.Contains(patchOptions.DryRun, metav1.DryRunAll) {
+					return errors.New("expected server-side apply dry-run")
+				}
+				return patchErr
+			},
+		}
+	}
+
+	for tname, tcase := range map[string]struct {
+		modifyRuntime        func(runtime *imv1.Runtime)
+		expectedChange       string
+		expectedNodesRollout bool
+	}{
+		"Should plan change of worker pool scaling without nodes rollout": {
+			modifyRuntime: func(runtime *imv1.Runtime) {
+				runtime.Spec.Shoot.Provider.Workers[0].Maximum = 5
+			},
+			expectedChange:       "spec/provider/workers/worker/maximum",
+			expectedNodesRollout: false,
+		},
+		"Should plan change of machine type with nodes rollout": {
+			modifyRuntime: func(runtime *imv1.Runtime) {
+				runtime.Spec.Shoot.Provider.Workers[0].Machine.Type = "m6i.xlarge"
+			},
+			expectedChange:       "spec/provider/workers/worker/machine/type",
+			expectedNodesRollout: true,
+		},
+		"Should plan kubernetes minor version upgrade with nodes rollout": {
+			modifyRuntime: func(runtime *imv1.Runtime) {
+				runtime.Spec.Shoot.Kubernetes.Version = ptr.To("1.30")
+			},
+			expectedChange:       "spec/kubernetes/version",
+			expectedNodesRollout: true,
+		},
+	} {
+		t.Run(tname, func(t *testing.T) {
+			// given
+			runtime := fixRuntime()
+			shoot := fixLiveShoot(t, *runtime.DeepCopy())
+			tcase.modifyRuntime(&runtime)
+
+			fsm, err := newFakeFSM(withFakedK8sClientInterceptor(dryRunPatch(nil), scheme, shoot, cloudProfile), withConverterConfig(converterConfig))
+			require.NoError(t, err)
+			state := &systemState{instance: runtime, shoot: shoot.DeepCopy()}
+
+			// when
+			stateFn, _, err := sFnPlanShootPatch(context.Background(), fsm, state)
+
+			// then
+			require.NoError(t, err)
+			require.Contains(t, stateFn.name(), "sFnUpdateStatus")
+
+			plan := state.instance.Status.Plan
+			require.NotNil(t, plan)
+			assert.Equal(t, int64(2), plan.ObservedGeneration)
+			assert.Equal(t, tcase.expectedNodesRollout, plan.NodesRollout)
+			require.Len(t, plan.Changes, 1)
+			assert.Equal(t, tcase.expectedChange, plan.Changes[0].Path)
+			assert.True(t, state.instance.IsConditionSetWithStatus(imv1.ConditionTypePlanned, imv1.ConditionReasonPlanCompleted, metav1.ConditionTrue))
+
+			var actualShoot gardener.Shoot
+			require.NoError(t, fsm.ShootClient.Get(context.Background(), client.ObjectKeyFromObject(shoot), &actualShoot))
+			assert.Equal(t, shoot.Spec, actualShoot.Spec)
+		})
+	}
+
+	for tname, tcase := range map[string]struct {
+		manager         string
+		expectedChanges []imv1.PlanChange
+	}{
+		"Should plan removal of worker pool applied by KIM": {
+			manager: "kim",
+			expectedChanges: []imv1.PlanChange{
+				{Path: "spec/provider/workers/second", Current: `{"name":"second","machine":{"type":"m6i.large"},"maximum":2,"minimum":1}`, Planned: "<nil>"},
+			},
+		},
+		"Should not plan removal of worker pool applied by another field manager": {
+			manager: "gardener",
+		},
+	} {
+		t.Run(tname, func(t *testing.T) {
+			// given
+			runtime := fixRuntime()
+			shoot := fixLiveShoot(t, *runtime.DeepCopy())
+			shoot.Spec.Provider.Workers = append(shoot.Spec.Provider.Workers, gardener.Worker{
+				Name:    "second",
+				Machine: gardener.Machine{Type: "m6i.large"},
+				Minimum: 1,
+				Maximum: 2,
+			})
+			shoot.ManagedFields = []metav1.ManagedFieldsEntry{
+				{
+					Manager:    tcase.manager,
+					Operation:  metav1.ManagedFieldsOperationApply,
+					FieldsType: "FieldsV1",
+					FieldsV1: &metav1.FieldsV1{
+						Raw: []byte(`{"f:spec":{"f:provider":{"f:workers":{"k:{\"name\":\"second\"}":{".":{},"f:name":{}},"k:{\"name\":\"worker\"}":{".":{},"f:name":{}}}}}}`),
+					},
+				},
+			}
+
+			fsm, err := newFakeFSM(withFakedK8sClientInterceptor(dryRunPatch(nil), scheme, shoot, cloudProfile), withConverterConfig(converterConfig))
+			require.NoError(t, err)
+			state := &systemState{instance: runtime, shoot: shoot.DeepCopy()}
+
+			// when
+			stateFn, _, err := sFnPlanShootPatch(context.Background(), fsm, state)
+
+			// then
+			require.NoError(t, err)
+			require.Contains(t, stateFn.name(), "sFnUpdateStatus")
+
+			plan := state.instance.Status.Plan
+			require.NotNil(t, plan)
+			assert.Equal(t, tcase.expectedChanges, plan.Changes)
+			assert.False(t, plan.NodesRollout)
+		})
+	}
+
+	for tname, tcase := range map[string]struct {
+		liveVersion      string
+		requestedVersion string
+		expectedInReason string
+	}{
+		"Should report rejected kubernetes downgrade": {
+			liveVersion:      "1.29.8",
+			requestedVersion: "1.28",
+			expectedInReason: "downgrade",
+		},
+		"Should report rejected skip of kubernetes minor version": {
+			liveVersion:      "1.29.8",
+			requestedVersion: "1.31",
+			expectedInReason: "skip",
+		},
+		"Should report rejected upgrade to expired kubernetes version": {
+			liveVersion:      "1.30.4",
+			requestedVersion: "1.31",
+			expectedInReason: "expired",
+		},
+	} {
+		t.Run(tname, func(t *testing.T) {
+			// given
+			runtime := fixRuntime()
+			shoot := fixLiveShoot(t, *runtime.DeepCopy())
+			shoot.Spec.Kubernetes.Version = tcase.liveVersion
+			runtime.Spec.Shoot.Kubernetes.Version = ptr.To(tcase.requestedVersion)
+
+			fsm, err := newFakeFSM(withFakedK8sClientInterceptor(dryRunPatch(nil), scheme, shoot, cloudProfile), withConverterConfig(converterConfig))
+			require.NoError(t, err)
+			state := &systemState{instance: runtime, shoot: shoot.DeepCopy()}
+
+			// when
+			stateFn, _, err := sFnPlanShootPatch(context.Background(), fsm, state)
+
+			// then
+			require.NoError(t, err)
+			require.Contains(t, stateFn.name(), "sFnUpdateStatus")
+
+			plan := state.instance.Status.Plan
+			require.NotNil(t, plan)
+			assert.Empty(t, plan.Changes)
+			assert.Contains(t, strings.ToLower(plan.Rejected), tcase.expectedInReason)
+			assert.True(t, state.instance.IsConditionSetWithStatus(imv1.ConditionTypePlanned, imv1.ConditionReasonPlanRejected, metav1.ConditionFalse))
+		})
+	}
+
+	t.Run("Should report shoot patch rejected by Gardener", func(t *testing.T) {
+		// given
+		runtime := fixRuntime()
+		runtime.Status.Plan = &imv1.PlanStatus{ObservedGeneration: 1}
+		shoot := fixLiveShoot(t, *runtime.DeepCopy())
+
+		rejected := apierrors.NewInvalid(schema.GroupKind{Group: "core.gardener.cloud", Kind: "Shoot"}, shoot.Name, nil)
+		fsm, err := newFakeFSM(withFakedK8sClientInterceptor(dryRunPatch(rejected), scheme, shoot, cloudProfile), withConverterConfig(converterConfig))
+		require.NoError(t, err)
+		state := &systemState{instance: runtime, shoot: shoot.DeepCopy()}
+
+		// when
+		stateFn, _, err := sFnPlanShootPatch(context.Background(), fsm, state)
+
+		// then
+		require.NoError(t, err)
+		require.Contains(t, stateFn.name(), "sFnUpdateStatus")
+		assert.Nil(t, state.instance.Status.Plan)
+		assert.Equal(t, imv1.State(""), state.instance.Status.State)
+
+		condition := meta.FindStatusCondition(state.instance.Status.Conditions, string(imv1.ConditionTypePlanned))
+		require.NotNil(t, condition)
+		assert.Equal(t, string(imv1.ConditionReasonPlanErr), condition.Reason)
+		assert.Contains(t, condition.Message, "Shoot dry-run patch error")
+	})
+}
@@ -0,0 +1,183 @@
+package fsm
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"net"
+	"time"
+
+	gardener "github.com/gardener/gardener/pkg/apis/core/v1beta1"
+	v1beta1constants "github.com/gardener/gardener/pkg/apis/core/v1beta1/constants"
+	imv1 "github.com/kyma-project/infrastructure-manager/api/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+	ctrl "sigs.k8s.io/controller-runtime"
+)
+
+// RetryPolicy defines how the failed operations of a single Runtime are retried
+type RetryPolicy struct {
+	// BaseDelay is the delay before the first retry, doubled for every next attempt
+	BaseDelay time.Duration
+	// MaxDelay limits the delay between attempts
+	MaxDelay time.Duration
+	// MaxAttempts is the number of retries after which the operation is considered as failed, 0 means no limit
+	MaxAttempts int32
+	// JitterFactor is the maximal fraction of the delay randomly added to it
+	JitterFactor float64
+}
+
+// Backoff returns the delay before the given attempt
+func (p RetryPolicy) Backoff(attempt int32) time.Duration {
+	if attempt < 1 {
+		attempt = 1
+	}
+
+	delay := time.Duration(float64(p.BaseDelay) * math.Pow(2, float64(attempt-1)))
+	if p.MaxDelay > 0 && (delay > p.MaxDelay || delay <= 0) {
+		delay = p.MaxDelay
+	}
+
+	if p.JitterFactor > 0 {
+		delay = wait.Jitter(delay, p.JitterFactor)
+	}
+
+	return delay
+}
+
+func (p RetryPolicy) attemptsExhausted(attempts int32) bool {
+	return p.MaxAttempts > 0 && attempts > p.MaxAttempts
+}
+
+// isRetryableLastError returns false when at least one of the error codes reported by Gardener
+// indicates that retrying without changing the Runtime (or the infrastructure account) will not help
+func isRetryableLastError(lastErrors ...gardener.LastError) bool {
+	for _, lastError := range lastErrors {
+		for _, code := range lastError.Codes {
+			switch code {
+			case gardener.ErrorInfraUnauthenticated,
+				gardener.ErrorInfraUnauthorized,
+				gardener.ErrorInfraDependencies,
+				gardener.ErrorInfraQuotaExceeded,
+				gardener.ErrorConfigurationProblem,
+				gardener.ErrorProblematicWebhook:
+				return false
+			}
+		}
+	}
+
+	// rate limits, retryable infrastructure and configuration problems, and errors without codes are transient
+	return true
+}
+
+// isRetryableAPIError returns true for the errors of the Gardener API calls which are expected to be transient
+func isRetryableAPIError(err error) bool {
+	if err == nil {
+		return false
+	}
+
+	if apierrors.IsConflict(err) ||
+		apierrors.IsServerTimeout(err) ||
+		apierrors.IsTimeout(err) ||
+		apierrors.IsTooManyRequests(err) ||
+		apierrors.IsServiceUnavailable(err) ||
+		apierrors.IsInternalError(err) ||
+		apierrors.IsUnexpectedServerError(err) {
+		return true
+	}
+
+	var netErr net.Error
+	return errors.As(err, &netErr)
+}
+
+// retryOrStop requeues the Runtime with exponential backoff when the error is retryable and the attempts budget is not exhausted,
+// otherwise the Runtime is marked as failed and the processing stops
+func retryOrStop(m *fsm, s *systemState, retryable bool, c imv1.RuntimeConditionType, r imv1.RuntimeConditionReason, msg string) (stateFn, *ctrl.Result, error) {
+	if !retryable {
+		m.log.Info("Non-retryable error, exiting with no retry", "reason", msg)
+		m.Metrics.IncRuntimeFSMStopCounter()
+		return updateStatePendingWithErrorAndStop(&s.instance, c, r, msg)
+	}
+
+	retry := s.instance.Status.Retry
+	if retry == nil {
+		retry = &imv1.RetryStatus{}
+	}
+
+	attempts := retry.Attempts + 1
+	if m.RetryPolicy.attemptsExhausted(attempts) {
+		m.log.Info("Retry attempts exhausted, exiting with no retry", "attempts", retry.Attempts, "reason", msg)
+		m.Metrics.IncRuntimeFSMStopCounter()
+		return updateStatePendingWithErrorAndStop(&s.instance, c, r, fmt.Sprintf("%s, giving up after %d attempts", msg, retry.Attempts))
+	}
+
+	delay := m.RetryPolicy.Backoff(attempts)
+	if delay <= 0 {
+		delay = m.RCCfg.GardenerRequeueDuration
+	}
+
+	s.instance.Status.Retry = &imv1.RetryStatus{
+		Attempts:      attempts,
+		NextRetryTime: &metav1.Time{Time: time.Now().Add(delay)},
+		Reason:        msg,
+		Generation:    s.instance.Generation,
+	}
+	s.instance.UpdateStatePending(c, r, "Unknown", fmt.Sprintf("%s, retry %d scheduled", msg, attempts))
+
+	m.log.Info("Retryable error, scheduling for retry", "attempt", attempts, "delay", delay, "reason", msg)
+	return updateStatusAndRequeueAfter(delay)
+}
+
+// retryShootOperationOrStop handles the failed shoot operation: the retry is scheduled with backoff by retryOrStop,
+// once it is due Gardener is requested to retry the operation, and its result is awaited before the next retry is scheduled
+func retryShootOperationOrStop(ctx context.Context, m *fsm, s *systemState, retryable bool, c imv1.RuntimeConditionType, r imv1.RuntimeConditionReason, msg string) (stateFn, *ctrl.Result, error) {
+	retry := s.instance.Status.Retry
+	if !retryable || retry == nil || retry.NextRetryTime == nil {
+		return retryOrStop(m, s, retryable, c, r, msg)
+	}
+
+	if delay := remainingRetryDelay(s.instance); delay > 0 {
+		return updateStatusAndRequeueAfter(delay)
+	}
+
+	// Gardener has not picked up the requested retry yet
+	if s.shoot.GetAnnotations()[v1beta1constants.GardenerOperation] == v1beta1constants.ShootOperationRetry {
+		return updateStatusAndRequeueAfter(m.RCCfg.GardenerRequeueDuration)
+	}
+
+	if err := requestShootRetry(ctx, m, s.shoot); err != nil {
+		m.log.Error(err, "Failed to request retry of the shoot operation")
+		return updateStatusAndRequeueAfter(m.RCCfg.GardenerRequeueDuration)
+	}
+
+	retry.NextRetryTime = nil
+	s.instance.UpdateStatePending(c, r, "Unknown", fmt.Sprintf("%s, retry %d in progress", msg, retry.Attempts))
+
+	m.log.Info("Requested Gardener to retry the failed shoot operation", "attempt", retry.Attempts, "Name", s.shoot.Name, "Namespace", s.shoot.Namespace)
+	return updateStatusAndRequeueAfter(m.RCCfg.GardenerRequeueDuration)
+}
+
+// resetRetries clears the retry status once the operation succeeded
+func resetRetries(s *systemState) {
+	s.instance.Status.Retry = nil
+}
+
+// remainingRetryDelay returns how long the processing of the Runtime must wait before the next attempt
+func remainingRetryDelay(instance imv1.Runtime) time.Duration {
+	retry := instance.Status.Retry
+	if retry == nil || retry.NextRetryTime == nil {
+		return 0
+	}
+
+	return time.Until(retry.NextRetryTime.Time)
+}
+
+func shootOperationProgressed(shoot *gardener.Shoot) bool {
+	if shoot == nil || shoot.Status.LastOperation == nil {
+		return false
+	}
+
+	return shoot.Status.LastOperation.State != gardener.LastOperationStateFailed
+}
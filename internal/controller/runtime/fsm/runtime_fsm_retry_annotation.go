@@ -0,0 +1,56 @@
+package fsm
+
+import (
+	"context"
+
+	gardener "github.com/gardener/gardener/pkg/apis/core/v1beta1"
+	v1beta1constants "github.com/gardener/gardener/pkg/apis/core/v1beta1/constants"
+	imv1 "github.com/kyma-project/infrastructure-manager/api/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func retryRequested(instance imv1.Runtime) bool {
+	_, found := instance.GetAnnotations()[imv1.AnnotationRetry]
+	return found
+}
+
+// handleRetryAnnotation processes the retry request set on the Runtime: the retry budget is reset,
+// Gardener is optionally asked to retry the failed shoot operation, and the annotation is removed
+func handleRetryAnnotation(ctx context.Context, m *fsm, s *systemState) error {
+	if s.instance.GetAnnotations()[imv1.AnnotationRetry] == imv1.AnnotationRetryValueShoot && shootOperationFailed(s.shoot) {
+		m.log.Info("Requesting Gardener to retry the failed shoot operation", "Name", s.shoot.Name, "Namespace", s.shoot.Namespace)
+
+		if err := requestShootRetry(ctx, m, s.shoot); err != nil {
+			return err
+		}
+	}
+
+	m.log.Info("Removing retry annotation")
+	annotations := s.instance.GetAnnotations()
+	delete(annotations, imv1.AnnotationRetry)
+	s.instance.SetAnnotations(annotations)
+
+	if err := m.Update(ctx, &s.instance); err != nil {
+		return err
+	}
+
+	resetRetries(s)
+	return nil
+}
+
+func shootOperationFailed(shoot *gardener.Shoot) bool {
+	return shoot != nil && shoot.Status.LastOperation != nil && shoot.Status.LastOperation.State == gardener.LastOperationStateFailed
+}
+
+// requestShootRetry sets the Gardener operation annotation asking Gardener to retry the failed operation of the shoot
+func requestShootRetry(ctx context.Context, m *fsm, shoot *gardener.Shoot) error {
+	patch := client.MergeFrom(shoot.DeepCopy())
+	annotations := shoot.GetAnnotations()
+	if annotations == nil {
+		annotations = map[string]string{}
+	}
+	annotations[v1beta1constants.GardenerOperation] = v1beta1constants.ShootOperationRetry
+	shoot.SetAnnotations(annotations)
+
+	return m.ShootClient.Patch(ctx, shoot, patch)
+}
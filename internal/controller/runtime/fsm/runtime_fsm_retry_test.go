@@ -0,0 +1,263 @@
+package fsm
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	gardener "github.com/gardener/gardener/pkg/apis/core/v1beta1"
+	v1beta1constants "github.com/gardener/gardener/pkg/apis/core/v1beta1/constants"
+	imv1 "github.com/kyma-project/infrastructure-manager/api/v1"
+	"github.com/kyma-project/infrastructure-manager/internal/controller/metrics/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func TestRetryPolicy(t *testing.T) {
+	policy := RetryPolicy{
+		BaseDelay: 10 * time.Second,
+		MaxDelay:  time.Minute,
+	}
+
+	t.Run("Should double the delay for every attempt", func(t *testing.T) {
+		assert.Equal(t, 10*time.Second, policy.Backoff(1))
+		assert.Equal(t, 20*time.Second, policy.Backoff(2))
+		assert.Equal(t, 40*time.Second, policy.Backoff(3))
+	})
+
+	t.Run("Should limit the delay", func(t *testing.T) {
+		assert.Equal(t, time.Minute, policy.Backoff(4))
+		assert.Equal(t, time.Minute, policy.Backoff(100))
+	})
+
+	t.Run("Should add jitter to the delay", func(t *testing.T) {
+		policyWithJitter := policy
+		policyWithJitter.JitterFactor = 0.5
+
+		delay := policyWithJitter.Backoff(1)
+		assert.GreaterOrEqual(t, delay, 10*time.Second)
+		assert.LessOrEqual(t, delay, 15*time.Second)
+	})
+}
+
+func TestRetryClassification(t *testing.T) {
+	for _, testCase := range []struct {
+		name       string
+		lastErrors []gardener.LastError
+		expected   bool
+	}{
+		{
+			name:     "error without codes is retryable",
+			expected: true,
+		},
+		{
+			name:       "rate limits exceeded is retryable",
+			lastErrors: []gardener.LastError{{Codes: []gardener.ErrorCode{gardener.ErrorInfraRateLimitsExceeded}}},
+			expected:   true,
+		},
+		{
+			name:       "retryable configuration problem is retryable",
+			lastErrors: []gardener.LastError{{Codes: []gardener.ErrorCode{gardener.ErrorRetryableConfigurationProblem}}},
+			expected:   true,
+		},
+		{
+			name:       "retryable infrastructure dependencies are retryable",
+			lastErrors: []gardener.LastError{{Codes: []gardener.ErrorCode{gardener.ErrorRetryableInfraDependencies}}},
+			expected:   true,
+		},
+		{
+			name:       "quota exceeded is not retryable",
+			lastErrors: []gardener.LastError{{Codes: []gardener.ErrorCode{gardener.ErrorInfraQuotaExceeded}}},
+			expected:   false,
+		},
+		{
+			name: "non-retryable code wins",
+			lastErrors: []gardener.LastError{
+				{Codes: []gardener.ErrorCode{gardener.ErrorInfraRateLimitsExceeded}},
+				{Codes: []gardener.ErrorCode{gardener.ErrorConfigurationProblem}},
+			},
+			expected: false,
+		},
+	} {
+		t.Run(testCase.name, func(t *testing.T) {
+			assert.Equal(t, testCase.expected, isRetryableLastError(testCase.lastErrors...))
+		})
+	}
+
+	gr := schema.GroupResource{Group: "core.gardener.cloud", Resource: "shoots"}
+
+	t.Run("API errors", func(t *testing.T) {
+		assert.True(t, isRetryableAPIError(apierrors.NewConflict(gr, "shoot", errors.New("conflict"))))
+		assert.True(t, isRetryableAPIError(apierrors.NewTooManyRequests("slow down", 1)))
+		assert.True(t, isRetryableAPIError(apierrors.NewServiceUnavailable("unavailable")))
+		assert.False(t, isRetryableAPIError(apierrors.NewForbidden(gr, "shoot", errors.New("forbidden"))))
+		assert.False(t, isRetryableAPIError(apierrors.NewBadRequest("invalid")))
+		assert.False(t, isRetryableAPIError(nil))
+	})
+}
+
+func TestRetryOrStop(t *testing.T) {
+	withMetrics := func() *mocks.Metrics {
+		m := &mocks.Metrics{}
+		m.On("IncRuntimeFSMStopCounter").Return()
+		return m
+	}
+
+	t.Run("Should schedule retry with backoff", func(t *testing.T) {
+		// given
+		fsm := &fsm{RCCfg: RCCfg{
+			Metrics:     withMetrics(),
+			RetryPolicy: RetryPolicy{BaseDelay: time.Minute, MaxAttempts: 3},
+		}}
+		systemState := &systemState{instance: runtimeForTest()}
+		systemState.instance.Generation = 2
+		systemState.instance.Status.Retry = &imv1.RetryStatus{Attempts: 1}
+
+		// when
+		stateFn, _, _ := retryOrStop(fsm, systemState, true, imv1.ConditionTypeRuntimeProvisioned, imv1.ConditionReasonCreationError, "Shoot creation failed")
+
+		// then
+		require.Contains(t, stateFn.name(), "sFnUpdateStatus")
+		result := runUpdateStatusResult(t, stateFn, fsm, systemState)
+		assert.Equal(t, 2*time.Minute, result.RequeueAfter)
+
+		retry := systemState.instance.Status.Retry
+		require.NotNil(t, retry)
+		assert.Equal(t, int32(2), retry.Attempts)
+		assert.Equal(t, "Shoot creation failed", retry.Reason)
+		assert.Equal(t, int64(2), retry.Generation)
+		require.NotNil(t, retry.NextRetryTime)
+		assert.WithinDuration(t, time.Now().Add(2*time.Minute), retry.NextRetryTime.Time, 5*time.Second)
+		assert.Equal(t, imv1.State(imv1.RuntimeStatePending), systemState.instance.Status.State)
+	})
+
+	t.Run("Should stop when attempts are exhausted", func(t *testing.T) {
+		// given
+		fsm := &fsm{RCCfg: RCCfg{
+			Metrics:     withMetrics(),
+			RetryPolicy: RetryPolicy{BaseDelay: time.Minute, MaxAttempts: 3},
+		}}
+		systemState := &systemState{instance: runtimeForTest()}
+		systemState.instance.Status.Retry = &imv1.RetryStatus{Attempts: 3}
+
+		// when
+		stateFn, _, _ := retryOrStop(fsm, systemState, true, imv1.ConditionTypeRuntimeProvisioned, imv1.ConditionReasonCreationError, "Shoot creation failed")
+
+		// then
+		require.Contains(t, stateFn.name(), "sFnUpdateStatus")
+		assert.Nil(t, runUpdateStatusResult(t, stateFn, fsm, systemState))
+		assert.Equal(t, imv1.State(imv1.RuntimeStateFailed), systemState.instance.Status.State)
+		condition := meta.FindStatusCondition(systemState.instance.Status.Conditions, string(imv1.ConditionTypeRuntimeProvisioned))
+		require.NotNil(t, condition)
+		assert.Contains(t, condition.Message, "giving up after 3 attempts")
+	})
+
+	t.Run("Should stop on non-retryable error", func(t *testing.T) {
+		// given
+		fsm := &fsm{RCCfg: RCCfg{
+			Metrics:     withMetrics(),
+			RetryPolicy: RetryPolicy{BaseDelay: time.Minute, MaxAttempts: 3},
+		}}
+		systemState := &systemState{instance: runtimeForTest()}
+
+		// when
+		stateFn, _, _ := retryOrStop(fsm, systemState, false, imv1.ConditionTypeRuntimeProvisioned, imv1.ConditionReasonCreationError, "Shoot creation failed")
+
+		// then
+		require.Contains(t, stateFn.name(), "sFnUpdateStatus")
+		assert.Nil(t, runUpdateStatusResult(t, stateFn, fsm, systemState))
+		assert.Nil(t, systemState.instance.Status.Retry)
+		assert.Equal(t, imv1.State(imv1.RuntimeStateFailed), systemState.instance.Status.State)
+	})
+}
+
+func TestRetryShootOperation(t *testing.T) {
+	scheme, err := newTestScheme()
+	require.NoError(t, err)
+	require.NoError(t, gardener.AddToScheme(scheme))
+
+	metrics := &mocks.Metrics{}
+	metrics.On("IncRuntimeFSMStopCounter").Return()
+
+	fixFailedShoot := func(annotations map[string]string) *gardener.Shoot {
+		return &gardener.Shoot{
+			ObjectMeta: metav1.ObjectMeta{Name: "test-shoot", Namespace: "garden-test", Annotations: annotations},
+			Status: gardener.ShootStatus{
+				LastOperation: &gardener.LastOperation{Type: gardener.LastOperationTypeReconcile, State: gardener.LastOperationStateFailed},
+				LastErrors:    []gardener.LastError{{Codes: []gardener.ErrorCode{gardener.ErrorInfraRateLimitsExceeded}}},
+			},
+		}
+	}
+
+	for tname, tcase := range map[string]struct {
+		retry                 *imv1.RetryStatus
+		shootAnnotations      map[string]string
+		expectedRetryAttempts int32
+		expectedRetryPending  bool
+		expectedOperation     string
+	}{
+		"Should schedule retry of failed shoot operation": {
+			expectedRetryAttempts: 1,
+			expectedRetryPending:  true,
+		},
+		"Should request Gardener to retry failed shoot operation once retry is due": {
+			retry:                 &imv1.RetryStatus{Attempts: 1, NextRetryTime: &metav1.Time{Time: time.Now().Add(-time.Second)}},
+			expectedRetryAttempts: 1,
+			expectedOperation:     v1beta1constants.ShootOperationRetry,
+		},
+		"Should wait until Gardener picks up requested retry": {
+			retry:                 &imv1.RetryStatus{Attempts: 1, NextRetryTime: &metav1.Time{Time: time.Now().Add(-time.Second)}},
+			shootAnnotations:      map[string]string{v1beta1constants.GardenerOperation: v1beta1constants.ShootOperationRetry},
+			expectedRetryAttempts: 1,
+			expectedRetryPending:  true,
+			expectedOperation:     v1beta1constants.ShootOperationRetry,
+		},
+		"Should schedule next retry when requested retry failed": {
+			retry:                 &imv1.RetryStatus{Attempts: 1},
+			expectedRetryAttempts: 2,
+			expectedRetryPending:  true,
+		},
+	} {
+		t.Run(tname, func(t *testing.T) {
+			// given
+			shoot := fixFailedShoot(tcase.shootAnnotations)
+			fsm, err := newFakeFSM(withFakedK8sClient(scheme, shoot), withMetrics(metrics), withDefaultReconcileDuration())
+			require.NoError(t, err)
+			fsm.RetryPolicy = RetryPolicy{BaseDelay: time.Minute, MaxAttempts: 3}
+
+			systemState := &systemState{instance: runtimeForTest(), shoot: shoot.DeepCopy()}
+			systemState.instance.Status.Retry = tcase.retry.DeepCopy()
+
+			// when
+			stateFn, _, err := sFnWaitForShootReconcile(context.Background(), fsm, systemState)
+
+			// then
+			require.NoError(t, err)
+			require.Contains(t, stateFn.name(), "sFnUpdateStatus")
+
+			retry := systemState.instance.Status.Retry
+			require.NotNil(t, retry)
+			assert.Equal(t, tcase.expectedRetryAttempts, retry.Attempts)
+			assert.Equal(t, tcase.expectedRetryPending, retry.NextRetryTime != nil)
+
+			var actualShoot gardener.Shoot
+			require.NoError(t, fsm.ShootClient.Get(context.Background(), client.ObjectKeyFromObject(shoot), &actualShoot))
+			assert.Equal(t, tcase.expectedOperation, actualShoot.Annotations[v1beta1constants.GardenerOperation])
+		})
+	}
+}
+
+// runUpdateStatusResult returns the result of the update status state without a status change, so no client is required
+func runUpdateStatusResult(t *testing.T, fn stateFn, m *fsm, s *systemState) *ctrl.Result {
+	s.snapshot = *s.instance.Status.DeepCopy()
+	_, result, err := fn(context.Background(), m, s)
+	require.NoError(t, err)
+	return result
+}
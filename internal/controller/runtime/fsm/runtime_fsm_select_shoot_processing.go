@@ -8,6 +8,8 @@ import (
 	gardener "github.com/gardener/gardener/pkg/apis/core/v1beta1"
 	imv1 "github.com/kyma-project/infrastructure-manager/api/v1"
 	"github.com/kyma-project/infrastructure-manager/pkg/gardener/shoot/extender"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	ctrl "sigs.k8s.io/controller-runtime"
 )
 
@@ -36,21 +38,72 @@ func sFnSelectShootProcessing(_ context.Context, m *fsm, s *systemState) (stateF
 		return updateStatusAndStop()
 	}
 
+	if s.instance.IsPlanRequested() {
+		if patchShoot {
+			m.log.Info("Gardener shoot already exists, planning update")
+			return switchState(sFnPlanShootPatch)
+		}
+
+		// nothing to apply, the empty plan confirms that the shoot is up to date
+		s.instance.Status.Plan = &imv1.PlanStatus{ObservedGeneration: s.instance.Generation}
+		setPlannedCondition(&s.instance, metav1.ConditionTrue, imv1.ConditionReasonPlanCompleted, planMessage(s.instance.Status.Plan))
+	} else {
+		clearPlan(s)
+	}
+
 	if patchShoot {
 		m.log.Info("Gardener shoot already exists, updating")
+
+		if isKubernetesVersionChangeRequested(m, s) {
+			return switchState(sFnValidateKubernetesUpgrade)
+		}
+
 		return switchState(sFnPatchExistingShoot)
 	}
 
-	if lastOperation.Type == gardener.LastOperationTypeCreate {
-		return switchState(sFnWaitForShootCreation)
+	if isShootHibernated(s.shoot) {
+		m.log.Info(fmt.Sprintf("Shoot %s is hibernated", s.shoot.Name))
+		s.instance.UpdateStateHibernated(
+			imv1.ConditionTypeRuntimeHibernated,
+			imv1.ConditionReasonHibernated,
+			"Shoot is hibernated",
+		)
+		return updateStatusAndStop()
 	}
 
-	if lastOperation.Type == gardener.LastOperationTypeReconcile {
-		return switchState(sFnWaitForShootReconcile)
+	// the shoot was woken up
+	meta.RemoveStatusCondition(&s.instance.Status.Conditions, string(imv1.ConditionTypeRuntimeHibernated))
+
+	next := shootOperationState(s)
+	if next == nil {
+		m.log.Info("Unknown shoot operation type, exiting with no retry")
+		return stopWithMetrics()
 	}
 
-	m.log.Info("Unknown shoot operation type, exiting with no retry")
-	return stopWithMetrics()
+	if m.RCCfg.DriftCheckInterval > 0 && lastOperation.State == gardener.LastOperationStateSucceeded {
+		return switchState(sFnCheckDrift)
+	}
+
+	return switchState(next)
+}
+
+// shootOperationState returns the state waiting for the last operation of the shoot, or nil for the unknown operation types
+func shootOperationState(s *systemState) stateFn {
+	switch s.shoot.Status.LastOperation.Type {
+	case gardener.LastOperationTypeCreate:
+		return sFnWaitForShootCreation
+	case gardener.LastOperationTypeReconcile:
+		return sFnWaitForShootReconcile
+	}
+
+	return nil
+}
+
+// the hibernated shoot has no running control plane, so the runtime cannot be configured until it is woken up
+func isShootHibernated(shoot *gardener.Shoot) bool {
+	return shoot.Status.IsHibernated &&
+		shoot.Status.LastOperation != nil &&
+		shoot.Status.LastOperation.State == gardener.LastOperationStateSucceeded
 }
 
 func shouldPatchShoot(runtime *imv1.Runtime, shoot *gardener.Shoot) (bool, error) {
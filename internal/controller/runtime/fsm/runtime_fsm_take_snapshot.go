@@ -4,6 +4,8 @@ import (
 	"context"
 
 	gardener_api "github.com/gardener/gardener/pkg/apis/core/v1beta1"
+	imv1 "github.com/kyma-project/infrastructure-manager/api/v1"
+	gardener_shoot "github.com/kyma-project/infrastructure-manager/pkg/gardener/shoot"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/types"
 	ctrl "sigs.k8s.io/controller-runtime"
@@ -21,7 +23,7 @@ func sFnTakeSnapshot(ctx context.Context, m *fsm, s *systemState) (stateFn, *ctr
 	var shoot gardener_api.Shoot
 	err := m.ShootClient.Get(ctx, types.NamespacedName{
 		Name:      s.instance.Spec.Shoot.Name,
-		Namespace: m.ShootNamesapace,
+		Namespace: shootNamespace(m, s.instance),
 	}, &shoot)
 
 	if err != nil && !apierrors.IsNotFound(err) {
@@ -33,5 +35,34 @@ func sFnTakeSnapshot(ctx context.Context, m *fsm, s *systemState) (stateFn, *ctr
 		s.shoot = &shoot
 	}
 
+	if retryRequested(s.instance) && s.instance.GetDeletionTimestamp().IsZero() {
+		if err := handleRetryAnnotation(ctx, m, s); err != nil {
+			m.log.Error(err, "Failed to process retry annotation")
+			return updateStatusAndStopWithError(err)
+		}
+	}
+
+	// a new generation of the Runtime gets a fresh retry budget
+	if retry := s.instance.Status.Retry; retry != nil && retry.Generation != s.instance.Generation {
+		resetRetries(s)
+	}
+
+	s.updateObservedShootStatus()
+
+	// the shoot events must not shorten the backoff, unless Gardener already moved on with the shoot
+	if delay := remainingRetryDelay(s.instance); delay > 0 && s.instance.GetDeletionTimestamp().IsZero() && !shootOperationProgressed(s.shoot) {
+		m.log.Info("Waiting for the next retry", "delay", delay)
+		return updateStatusAndRequeueAfter(delay)
+	}
+
 	return switchState(sFnInitialize)
 }
+
+// shootNamespace returns the namespace of the Gardener project selected in the Runtime, or the default shoot namespace
+func shootNamespace(m *fsm, instance imv1.Runtime) string {
+	if instance.Spec.Shoot.ProjectName == "" {
+		return m.ShootNamesapace
+	}
+
+	return gardener_shoot.ShootNamespace(instance, "")
+}
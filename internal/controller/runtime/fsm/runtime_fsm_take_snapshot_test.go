@@ -0,0 +1,222 @@
+package fsm
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	gardener "github.com/gardener/gardener/pkg/apis/core/v1beta1"
+	v1beta1constants "github.com/gardener/gardener/pkg/apis/core/v1beta1/constants"
+	imv1 "github.com/kyma-project/infrastructure-manager/api/v1"
+	"github.com/kyma-project/infrastructure-manager/pkg/gardener/shoot/extender"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestTakeSnapshotState(t *testing.T) {
+	t.Run("Should copy observed shoot details to the runtime status", func(t *testing.T) {
+		// given
+		ctx := context.Background()
+		scheme := runtime.NewScheme()
+		require.NoError(t, gardener.AddToScheme(scheme))
+
+		shootStub := shootForTest()
+		shootStub.Annotations = map[string]string{
+			extender.ShootRuntimeGenerationAnnotation: "3",
+		}
+		shootStub.Spec.SeedName = ptr.To("aws-eu1")
+		shootStub.Spec.DNS = &gardener.DNS{Domain: ptr.To("test-shoot.kyma.example.com")}
+		shootStub.Spec.Kubernetes.Version = "1.30.2"
+		shootStub.Spec.Provider.Workers = []gardener.Worker{
+			{
+				Name: "cpu-worker-0",
+				Machine: gardener.Machine{
+					Image: &gardener.ShootMachineImage{
+						Name:    "gardenlinux",
+						Version: ptr.To("1592.1.0"),
+					},
+				},
+			},
+		}
+		shootStub.Status.LastOperation = &gardener.LastOperation{
+			Type:  gardener.LastOperationTypeReconcile,
+			State: gardener.LastOperationStateFailed,
+		}
+		shootStub.Status.LastErrors = []gardener.LastError{
+			{
+				Description: "infrastructure quota exceeded",
+				Codes:       []gardener.ErrorCode{gardener.ErrorInfraQuotaExceeded},
+			},
+		}
+
+		fakeClient := fake.NewClientBuilder().
+			WithScheme(scheme).
+			WithObjects(shootStub).
+			Build()
+
+		fsm := &fsm{
+			K8s:   K8s{ShootClient: fakeClient},
+			RCCfg: RCCfg{ShootNamesapace: shootStub.Namespace},
+		}
+
+		runtimeStub := runtimeForTest()
+		runtimeStub.Generation = 4
+		runtimeStub.Labels = map[string]string{imv1.LabelControlledByProvisioner: "false"}
+		systemState := &systemState{instance: runtimeStub}
+
+		// when
+		stateFn, _, err := sFnTakeSnapshot(ctx, fsm, systemState)
+
+		// then
+		require.NoError(t, err)
+		require.Contains(t, stateFn.name(), "sFnInitialize")
+
+		status := systemState.instance.Status
+		// the generation 4 is not applied to the shoot yet
+		assert.Equal(t, int64(3), status.ObservedGeneration)
+		require.NotNil(t, status.Shoot)
+		assert.Equal(t, int64(3), status.Shoot.AppliedRuntimeGeneration)
+		assert.Equal(t, "aws-eu1", status.Shoot.SeedName)
+		assert.Equal(t, "test-shoot.kyma.example.com", status.Shoot.Domain)
+		assert.Equal(t, "1.30.2", status.Shoot.KubernetesVersion)
+		assert.Equal(t, []imv1.WorkerStatus{
+			{
+				Name:                "cpu-worker-0",
+				MachineImageName:    "gardenlinux",
+				MachineImageVersion: "1592.1.0",
+			},
+		}, status.Shoot.Workers)
+		assert.Equal(t, shootStub.Status.LastOperation, status.Shoot.LastOperation)
+		assert.Equal(t, shootStub.Status.LastErrors, status.Shoot.LastErrors)
+	})
+
+	t.Run("Should not set shoot details when shoot does not exist", func(t *testing.T) {
+		// given
+		ctx := context.Background()
+		scheme := runtime.NewScheme()
+		require.NoError(t, gardener.AddToScheme(scheme))
+
+		fakeClient := fake.NewClientBuilder().
+			WithScheme(scheme).
+			Build()
+
+		fsm := &fsm{
+			K8s:   K8s{ShootClient: fakeClient},
+			RCCfg: RCCfg{ShootNamesapace: "namespace"},
+		}
+
+		runtimeStub := runtimeForTest()
+		runtimeStub.Generation = 1
+		runtimeStub.Labels = map[string]string{imv1.LabelControlledByProvisioner: "false"}
+		systemState := &systemState{instance: runtimeStub}
+
+		// when
+		stateFn, _, err := sFnTakeSnapshot(ctx, fsm, systemState)
+
+		// then
+		require.NoError(t, err)
+		require.Contains(t, stateFn.name(), "sFnInitialize")
+		assert.Equal(t, int64(0), systemState.instance.Status.ObservedGeneration)
+		assert.Nil(t, systemState.instance.Status.Shoot)
+	})
+
+	t.Run("Should reset retries only for new generation of Runtime", func(t *testing.T) {
+		// given
+		ctx := context.Background()
+		scheme := runtime.NewScheme()
+		require.NoError(t, gardener.AddToScheme(scheme))
+
+		shootStub := shootForTest()
+		shootStub.Annotations = map[string]string{
+			extender.ShootRuntimeGenerationAnnotation: "1",
+		}
+
+		fakeClient := fake.NewClientBuilder().
+			WithScheme(scheme).
+			WithObjects(shootStub).
+			Build()
+
+		fsm := &fsm{
+			K8s:   K8s{ShootClient: fakeClient},
+			RCCfg: RCCfg{ShootNamesapace: shootStub.Namespace},
+		}
+
+		for _, tcase := range []struct {
+			retryGeneration int64
+			expectedReset   bool
+		}{
+			{retryGeneration: 2, expectedReset: false},
+			{retryGeneration: 1, expectedReset: true},
+		} {
+			runtimeStub := runtimeForTest()
+			runtimeStub.Generation = 2
+			runtimeStub.Labels = map[string]string{imv1.LabelControlledByProvisioner: "false"}
+			runtimeStub.Status.Retry = &imv1.RetryStatus{Attempts: 2, Generation: tcase.retryGeneration}
+			systemState := &systemState{instance: runtimeStub}
+
+			// when
+			_, _, err := sFnTakeSnapshot(ctx, fsm, systemState)
+
+			// then
+			require.NoError(t, err)
+			assert.Equal(t, tcase.expectedReset, systemState.instance.Status.Retry == nil)
+			assert.Equal(t, int64(1), systemState.instance.Status.ObservedGeneration)
+		}
+	})
+
+	t.Run("Should process retry annotation", func(t *testing.T) {
+		// given
+		ctx := context.Background()
+		scheme := runtime.NewScheme()
+		require.NoError(t, gardener.AddToScheme(scheme))
+		require.NoError(t, imv1.AddToScheme(scheme))
+
+		shootStub := shootForTest()
+		shootStub.Status.LastOperation = &gardener.LastOperation{
+			Type:  gardener.LastOperationTypeCreate,
+			State: gardener.LastOperationStateFailed,
+		}
+
+		runtimeStub := runtimeForTest()
+		runtimeStub.Labels = map[string]string{imv1.LabelControlledByProvisioner: "false"}
+		runtimeStub.Annotations = map[string]string{imv1.AnnotationRetry: imv1.AnnotationRetryValueShoot}
+		runtimeStub.Status.Retry = &imv1.RetryStatus{
+			Attempts:      10,
+			NextRetryTime: &metav1.Time{Time: time.Now().Add(time.Hour)},
+		}
+
+		fakeClient := fake.NewClientBuilder().
+			WithScheme(scheme).
+			WithObjects(shootStub, &runtimeStub).
+			WithStatusSubresource(&runtimeStub).
+			Build()
+
+		fsm := &fsm{
+			K8s:   K8s{Client: fakeClient, ShootClient: fakeClient},
+			RCCfg: RCCfg{ShootNamesapace: shootStub.Namespace},
+		}
+
+		systemState := &systemState{instance: runtimeStub}
+
+		// when
+		stateFn, _, err := sFnTakeSnapshot(ctx, fsm, systemState)
+
+		// then
+		require.NoError(t, err)
+		require.Contains(t, stateFn.name(), "sFnInitialize")
+		assert.Nil(t, systemState.instance.Status.Retry)
+
+		var updatedRuntime imv1.Runtime
+		require.NoError(t, fakeClient.Get(ctx, client.ObjectKeyFromObject(&runtimeStub), &updatedRuntime))
+		assert.NotContains(t, updatedRuntime.Annotations, imv1.AnnotationRetry)
+
+		var updatedShoot gardener.Shoot
+		require.NoError(t, fakeClient.Get(ctx, client.ObjectKeyFromObject(shootStub), &updatedShoot))
+		assert.Equal(t, v1beta1constants.ShootOperationRetry, updatedShoot.Annotations[v1beta1constants.GardenerOperation])
+	})
+}
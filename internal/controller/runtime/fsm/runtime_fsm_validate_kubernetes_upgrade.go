@@ -0,0 +1,77 @@
+package fsm
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	imv1 "github.com/kyma-project/infrastructure-manager/api/v1"
+	"github.com/kyma-project/infrastructure-manager/pkg/gardener/cloudprofile"
+	"github.com/kyma-project/infrastructure-manager/pkg/gardener/shoot/extender"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+)
+
+func sFnValidateKubernetesUpgrade(ctx context.Context, m *fsm, s *systemState) (stateFn, *ctrl.Result, error) {
+	m.log.Info("Validate kubernetes upgrade state")
+
+	currentVersion := s.shoot.Spec.Kubernetes.Version
+	requestedVersion := requestedKubernetesVersion(m, s)
+
+	err := loadCloudProfile(ctx, m, s)
+	if err != nil {
+		m.log.Error(err, "Failed to get cloud profile")
+		return retryOrStop(m, s, isRetryableAPIError(err), imv1.ConditionTypeKubernetesUpgraded, imv1.ConditionReasonKubernetesUpgradeErr, fmt.Sprintf("Cloud profile read error: %v", err))
+	}
+
+	err = cloudprofile.ValidateKubernetesUpgrade(*s.cloudProfile, currentVersion, requestedVersion, time.Now())
+	if err != nil {
+		m.log.Info("Kubernetes upgrade rejected, exiting with no retry", "reason", err.Error())
+		m.Metrics.IncRuntimeFSMStopCounter()
+		return updateStatePendingWithErrorAndStop(&s.instance, imv1.ConditionTypeKubernetesUpgraded, imv1.ConditionReasonKubernetesUpgradeRejected, fmt.Sprintf("Kubernetes upgrade rejected: %v", err))
+	}
+
+	s.instance.UpdateStatePending(
+		imv1.ConditionTypeKubernetesUpgraded,
+		imv1.ConditionReasonKubernetesUpgradeInProgress,
+		"Unknown",
+		fmt.Sprintf("Kubernetes is being upgraded from %s to %s", currentVersion, requestedVersion),
+	)
+
+	return switchState(sFnPatchExistingShoot)
+}
+
+func requestedKubernetesVersion(m *fsm, s *systemState) string {
+	return extender.KubernetesVersionOrDefault(s.instance, m.Config.ConverterConfig.Kubernetes.DefaultVersion)
+}
+
+// isKubernetesVersionChangeRequested returns true when the Runtime requests another kubernetes version than the one the shoot runs on,
+// downgrades and unparsable versions are also reported to be rejected by the validation
+func isKubernetesVersionChangeRequested(m *fsm, s *systemState) bool {
+	currentVersion := s.shoot.Spec.Kubernetes.Version
+	requestedVersion := requestedKubernetesVersion(m, s)
+
+	if currentVersion == "" || requestedVersion == "" || currentVersion == requestedVersion {
+		return false
+	}
+
+	changed, err := cloudprofile.IsKubernetesVersionChanged(currentVersion, requestedVersion)
+	return changed || err != nil
+}
+
+func isKubernetesUpgradeInProgress(instance imv1.Runtime) bool {
+	condition := meta.FindStatusCondition(instance.Status.Conditions, string(imv1.ConditionTypeKubernetesUpgraded))
+	return condition != nil && condition.Status == metav1.ConditionUnknown
+}
+
+// setKubernetesUpgradedCondition reports the result of the upgrade without changing the Runtime state, which is driven by the Provisioned condition
+func setKubernetesUpgradedCondition(instance *imv1.Runtime, status metav1.ConditionStatus, r imv1.RuntimeConditionReason, msg string) {
+	meta.SetStatusCondition(&instance.Status.Conditions, metav1.Condition{
+		Type:               string(imv1.ConditionTypeKubernetesUpgraded),
+		Status:             status,
+		LastTransitionTime: metav1.Now(),
+		Reason:             string(r),
+		Message:            msg,
+	})
+}
@@ -0,0 +1,126 @@
+package fsm
+
+import (
+	"context"
+	"testing"
+
+	gardener "github.com/gardener/gardener/pkg/apis/core/v1beta1"
+	imv1 "github.com/kyma-project/infrastructure-manager/api/v1"
+	"github.com/kyma-project/infrastructure-manager/internal/controller/metrics/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestValidateKubernetesUpgradeState(t *testing.T) {
+	for tname, tc := range map[string]struct {
+		requestedVersion string
+		expectedState    string
+		expectedStatus   metav1.ConditionStatus
+		expectedReason   imv1.RuntimeConditionReason
+	}{
+		"Should continue with shoot patch for valid upgrade": {
+			requestedVersion: "1.30",
+			expectedState:    "sFnPatchExistingShoot",
+			expectedStatus:   metav1.ConditionUnknown,
+			expectedReason:   imv1.ConditionReasonKubernetesUpgradeInProgress,
+		},
+		"Should reject upgrade skipping minor version": {
+			requestedVersion: "1.31",
+			expectedState:    "sFnUpdateStatus",
+			expectedStatus:   metav1.ConditionFalse,
+			expectedReason:   imv1.ConditionReasonKubernetesUpgradeRejected,
+		},
+		"Should reject downgrade": {
+			requestedVersion: "1.28",
+			expectedState:    "sFnUpdateStatus",
+			expectedStatus:   metav1.ConditionFalse,
+			expectedReason:   imv1.ConditionReasonKubernetesUpgradeRejected,
+		},
+	} {
+		t.Run(tname, func(t *testing.T) {
+			// given
+			ctx := context.Background()
+			scheme := runtime.NewScheme()
+			require.NoError(t, gardener.AddToScheme(scheme))
+
+			cloudProfile := &gardener.CloudProfile{
+				ObjectMeta: metav1.ObjectMeta{Name: "aws"},
+				Spec: gardener.CloudProfileSpec{
+					Kubernetes: gardener.KubernetesSettings{
+						Versions: []gardener.ExpirableVersion{
+							{Version: "1.31.1"},
+							{Version: "1.30.4"},
+							{Version: "1.29.8"},
+						},
+					},
+				},
+			}
+
+			metricsMock := &mocks.Metrics{}
+			metricsMock.On("IncRuntimeFSMStopCounter").Return().Maybe()
+
+			fsm := &fsm{K8s: K8s{
+				ShootClient: fake.NewClientBuilder().WithScheme(scheme).WithObjects(cloudProfile).Build(),
+			}}
+			fsm.Metrics = metricsMock
+
+			runtimeStub := runtimeForTest()
+			runtimeStub.Spec.Shoot.Kubernetes.Version = ptr.To(tc.requestedVersion)
+
+			shootStub := shootForTest()
+			shootStub.Spec.Kubernetes.Version = "1.29.8"
+
+			systemState := &systemState{
+				instance: runtimeStub,
+				shoot:    shootStub,
+			}
+
+			// when
+			stateFn, _, _ := sFnValidateKubernetesUpgrade(ctx, fsm, systemState)
+
+			// then
+			require.Contains(t, stateFn.name(), tc.expectedState)
+
+			condition := meta.FindStatusCondition(systemState.instance.Status.Conditions, string(imv1.ConditionTypeKubernetesUpgraded))
+			require.NotNil(t, condition)
+			assert.Equal(t, tc.expectedStatus, condition.Status)
+			assert.Equal(t, string(tc.expectedReason), condition.Reason)
+			metricsMock.AssertExpectations(t)
+		})
+	}
+}
+
+func TestKubernetesVersionChangeRequested(t *testing.T) {
+	fsm := &fsm{}
+	fsm.Config.ConverterConfig.Kubernetes.DefaultVersion = "1.29"
+
+	for tname, tc := range map[string]struct {
+		requestedVersion *string
+		expected         bool
+	}{
+		"Should detect upgrade":                          {requestedVersion: ptr.To("1.30"), expected: true},
+		"Should detect downgrade":                        {requestedVersion: ptr.To("1.28.10"), expected: true},
+		"Should ignore default version of running minor": {requestedVersion: nil, expected: false},
+		"Should ignore patch updated during maintenance": {requestedVersion: ptr.To("1.29.5"), expected: false},
+	} {
+		t.Run(tname, func(t *testing.T) {
+			// given
+			runtimeStub := runtimeForTest()
+			runtimeStub.Spec.Shoot.Kubernetes.Version = tc.requestedVersion
+
+			shootStub := shootForTest()
+			shootStub.Spec.Kubernetes.Version = "1.29.8"
+
+			// when
+			changed := isKubernetesVersionChangeRequested(fsm, &systemState{instance: runtimeStub, shoot: shootStub})
+
+			// then
+			assert.Equal(t, tc.expected, changed)
+		})
+	}
+}
@@ -6,10 +6,11 @@ import (
 
 	gardener "github.com/gardener/gardener/pkg/apis/core/v1beta1"
 	imv1 "github.com/kyma-project/infrastructure-manager/api/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	ctrl "sigs.k8s.io/controller-runtime"
 )
 
-func sFnWaitForShootReconcile(_ context.Context, m *fsm, s *systemState) (stateFn, *ctrl.Result, error) {
+func sFnWaitForShootReconcile(ctx context.Context, m *fsm, s *systemState) (stateFn, *ctrl.Result, error) {
 	m.log.Info("Waiting for shoot reconcile state")
 
 	switch s.shoot.Status.LastOperation.State {
@@ -31,20 +32,29 @@ func sFnWaitForShootReconcile(_ context.Context, m *fsm, s *systemState) (stateF
 			reason = gardenerErrCodesToErrReason(s.shoot.Status.LastErrors...)
 		}
 
-		msg := fmt.Sprintf("error during cluster processing: reconcilation failed for shoot %s, reason: %s, exiting with no retry", s.shoot.Name, reason)
+		msg := fmt.Sprintf("error during cluster processing: reconcilation failed for shoot %s, reason: %s", s.shoot.Name, reason)
 		m.log.Info(msg)
 
-		s.instance.UpdateStatePending(
+		if isKubernetesUpgradeInProgress(s.instance) {
+			setKubernetesUpgradedCondition(&s.instance, metav1.ConditionFalse, imv1.ConditionReasonKubernetesUpgradeErr,
+				fmt.Sprintf("Kubernetes upgrade to %s failed, reason: %s", s.shoot.Spec.Kubernetes.Version, reason))
+		}
+
+		return retryShootOperationOrStop(ctx, m, s, isRetryableLastError(s.shoot.Status.LastErrors...),
 			imv1.ConditionTypeRuntimeProvisioned,
 			imv1.ConditionReasonProcessingErr,
-			"False",
 			string(reason),
 		)
-		m.Metrics.IncRuntimeFSMStopCounter()
-		return updateStatusAndStop()
 
 	case gardener.LastOperationStateSucceeded:
 		m.log.Info(fmt.Sprintf("Shoot %s successfully updated, moving to processing", s.shoot.Name))
+		resetRetries(s)
+
+		if isKubernetesUpgradeInProgress(s.instance) {
+			setKubernetesUpgradedCondition(&s.instance, metav1.ConditionTrue, imv1.ConditionReasonKubernetesUpgraded,
+				fmt.Sprintf("Kubernetes upgraded to %s", s.shoot.Spec.Kubernetes.Version))
+		}
+
 		return ensureStatusConditionIsSetAndContinue(
 			&s.instance,
 			imv1.ConditionTypeRuntimeProvisioned,
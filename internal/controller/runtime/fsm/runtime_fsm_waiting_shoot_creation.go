@@ -6,7 +6,6 @@ import (
 	"strings"
 
 	gardener "github.com/gardener/gardener/pkg/apis/core/v1beta1"
-	gardenerhelper "github.com/gardener/gardener/pkg/apis/core/v1beta1/helper"
 	imv1 "github.com/kyma-project/infrastructure-manager/api/v1"
 	ctrl "sigs.k8s.io/controller-runtime"
 )
@@ -27,7 +26,7 @@ func ensureTerminatingStatusConditionAndContinue(instance *imv1.Runtime, condTyp
 	return switchState(next)
 }
 
-func sFnWaitForShootCreation(_ context.Context, m *fsm, s *systemState) (stateFn, *ctrl.Result, error) {
+func sFnWaitForShootCreation(ctx context.Context, m *fsm, s *systemState) (stateFn, *ctrl.Result, error) {
 	m.log.Info("Waiting for shoot creation state")
 
 	switch s.shoot.Status.LastOperation.State {
@@ -43,29 +42,22 @@ func sFnWaitForShootCreation(_ context.Context, m *fsm, s *systemState) (stateFn
 		return updateStatusAndRequeueAfter(m.RCCfg.GardenerRequeueDuration)
 
 	case gardener.LastOperationStateFailed:
-		if gardenerhelper.HasErrorCode(s.shoot.Status.LastErrors, gardener.ErrorInfraRateLimitsExceeded) {
-			m.log.Info(fmt.Sprintf("Error during cluster provisioning: Rate limits exceeded for Shoot %s, scheduling for retry", s.shoot.Name))
-			return updateStatusAndRequeueAfter(m.RCCfg.GardenerRequeueDuration)
-		}
-
-		// also handle other retryable errors here
-		// ErrorRetryableConfigurationProblem
-		// ErrorRetryableInfraDependencies
-
 		msg := fmt.Sprintf("Provisioning failed for shoot: %s ! Last state: %s, Description: %s", s.shoot.Name, s.shoot.Status.LastOperation.State, s.shoot.Status.LastOperation.Description)
 		m.log.Info(msg)
 
-		s.instance.UpdateStatePending(
+		reason := "Shoot creation failed"
+		if len(s.shoot.Status.LastErrors) > 0 {
+			reason = fmt.Sprintf("%s: %s", reason, gardenerErrCodesToErrReason(s.shoot.Status.LastErrors...))
+		}
+
+		return retryShootOperationOrStop(ctx, m, s, isRetryableLastError(s.shoot.Status.LastErrors...),
 			imv1.ConditionTypeRuntimeProvisioned,
 			imv1.ConditionReasonCreationError,
-			"False",
-			"Shoot creation failed")
-
-		m.Metrics.IncRuntimeFSMStopCounter()
-		return updateStatusAndStop()
+			reason)
 
 	case gardener.LastOperationStateSucceeded:
 		m.log.Info(fmt.Sprintf("Shoot %s successfully created", s.shoot.Name))
+		resetRetries(s)
 		return ensureStatusConditionIsSetAndContinue(
 			&s.instance,
 			imv1.ConditionTypeRuntimeProvisioned,
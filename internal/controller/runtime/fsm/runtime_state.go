@@ -1,8 +1,11 @@
 package fsm
 
 import (
+	"strconv"
+
 	gardener_api "github.com/gardener/gardener/pkg/apis/core/v1beta1"
 	imv1 "github.com/kyma-project/infrastructure-manager/api/v1"
+	"github.com/kyma-project/infrastructure-manager/pkg/gardener/shoot/extender"
 )
 
 // the state of controlled system (k8s cluster)
@@ -10,6 +13,10 @@ type systemState struct {
 	instance imv1.Runtime
 	snapshot imv1.RuntimeStatus
 	shoot    *gardener_api.Shoot
+	// cloudProfile is read from Gardener when the shoot is created or updated
+	cloudProfile *gardener_api.CloudProfile
+	// driftCorrection is set when the shoot is patched to correct the drift detected for the Ready runtime
+	driftCorrection bool
 }
 
 func (s *systemState) saveRuntimeStatus() {
@@ -19,3 +26,58 @@ func (s *systemState) saveRuntimeStatus() {
 	}
 	s.snapshot = *result
 }
+
+// markGenerationApplied reports the generation of the Runtime as observed, once it is applied to the shoot
+func (s *systemState) markGenerationApplied() {
+	s.instance.Status.ObservedGeneration = s.instance.Generation
+}
+
+// copies the details of the observed shoot to the runtime status
+func (s *systemState) updateObservedShootStatus() {
+	if s.shoot == nil {
+		return
+	}
+
+	shootStatus := &imv1.ShootStatus{
+		KubernetesVersion: s.shoot.Spec.Kubernetes.Version,
+	}
+
+	appliedGeneration, err := strconv.ParseInt(s.shoot.GetAnnotations()[extender.ShootRuntimeGenerationAnnotation], 10, 64)
+	if err == nil {
+		shootStatus.AppliedRuntimeGeneration = appliedGeneration
+		s.instance.Status.ObservedGeneration = appliedGeneration
+	}
+
+	if s.shoot.Spec.SeedName != nil {
+		shootStatus.SeedName = *s.shoot.Spec.SeedName
+	}
+
+	if s.shoot.Spec.DNS != nil && s.shoot.Spec.DNS.Domain != nil {
+		shootStatus.Domain = *s.shoot.Spec.DNS.Domain
+	}
+
+	if s.shoot.Status.LastOperation != nil {
+		shootStatus.LastOperation = s.shoot.Status.LastOperation.DeepCopy()
+	}
+
+	for _, lastError := range s.shoot.Status.LastErrors {
+		shootStatus.LastErrors = append(shootStatus.LastErrors, *lastError.DeepCopy())
+	}
+
+	for _, worker := range s.shoot.Spec.Provider.Workers {
+		workerStatus := imv1.WorkerStatus{
+			Name: worker.Name,
+		}
+
+		if worker.Machine.Image != nil {
+			workerStatus.MachineImageName = worker.Machine.Image.Name
+			if worker.Machine.Image.Version != nil {
+				workerStatus.MachineImageVersion = *worker.Machine.Image.Version
+			}
+		}
+
+		shootStatus.Workers = append(shootStatus.Workers, workerStatus)
+	}
+
+	s.instance.Status.Shoot = shootStatus
+}
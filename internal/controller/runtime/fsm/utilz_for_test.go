@@ -17,6 +17,7 @@ import (
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
 	"sigs.k8s.io/controller-runtime/pkg/log/zap"
 )
 
@@ -88,6 +89,39 @@ var (
 		}
 	}
 
+	withFakedK8sClientInterceptor = func(
+		funcs interceptor.Funcs,
+		scheme *runtime.Scheme,
+		objs ...client.Object) fakeFSMOpt {
+
+		k8sClient := fake.NewClientBuilder().
+			WithScheme(scheme).
+			WithObjects(objs...).
+			WithStatusSubresource(objs...).
+			WithInterceptorFuncs(funcs).
+			Build()
+
+		return func(fsm *fsm) error {
+			fsm.Client = k8sClient
+			fsm.ShootClient = k8sClient
+			return nil
+		}
+	}
+
+	withDeletionGracePeriod = func(gracePeriod time.Duration) fakeFSMOpt {
+		return func(fsm *fsm) error {
+			fsm.DeletionGracePeriod = gracePeriod
+			return nil
+		}
+	}
+
+	withDriftCheckInterval = func(interval time.Duration) fakeFSMOpt {
+		return func(fsm *fsm) error {
+			fsm.DriftCheckInterval = interval
+			return nil
+		}
+	}
+
 	withFn = func(fn stateFn) fakeFSMOpt {
 		return func(fsm *fsm) error {
 			fsm.fn = fn
@@ -20,6 +20,7 @@ import (
 	"context"
 	"fmt"
 
+	gardener_api "github.com/gardener/gardener/pkg/apis/core/v1beta1"
 	"github.com/go-logr/logr"
 	imv1 "github.com/kyma-project/infrastructure-manager/api/v1"
 	"github.com/kyma-project/infrastructure-manager/internal/controller/runtime/fsm"
@@ -27,7 +28,10 @@ import (
 	"k8s.io/client-go/tools/record"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/cluster"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/source"
 )
 
 // RuntimeReconciler reconciles a Runtime object
@@ -88,13 +92,25 @@ func (r *RuntimeReconciler) UpdateShootClient(client client.Client) {
 }
 
 // SetupWithManager sets up the controller with the Manager.
-func (r *RuntimeReconciler) SetupWithManager(mgr ctrl.Manager) error {
-	return ctrl.NewControllerManagedBy(mgr).
+// When shootCluster is provided, changes of the Gardener shoots are watched and trigger the reconciliation of the owning Runtime,
+// otherwise the controller relies on requeueing only.
+func (r *RuntimeReconciler) SetupWithManager(mgr ctrl.Manager, shootCluster cluster.Cluster) error {
+	builder := ctrl.NewControllerManagedBy(mgr).
 		For(&imv1.Runtime{}).
 		WithEventFilter(predicate.Or(
 			predicate.GenerationChangedPredicate{},
 			predicate.LabelChangedPredicate{},
 			predicate.AnnotationChangedPredicate{},
-		)).
-		Complete(r)
+		))
+
+	if shootCluster != nil {
+		builder = builder.WatchesRawSource(source.Kind(
+			shootCluster.GetCache(),
+			&gardener_api.Shoot{},
+			handler.TypedEnqueueRequestsFromMapFunc(r.mapShootToRuntime),
+			shootChangedPredicate(),
+		))
+	}
+
+	return builder.Complete(r)
 }
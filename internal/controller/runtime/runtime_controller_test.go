@@ -256,8 +256,12 @@ func CreateRuntimeStub(resourceName string) *imv1.Runtime {
 		},
 		Spec: imv1.RuntimeSpec{
 			Shoot: imv1.RuntimeShoot{
-				Name:       resourceName,
-				Networking: imv1.Networking{},
+				Name: resourceName,
+				Networking: imv1.Networking{
+					Nodes:    "10.250.0.0/16",
+					Pods:     "100.64.0.0/12",
+					Services: "100.104.0.0/13",
+				},
 				Provider: imv1.Provider{
 					Type: "aws",
 					Workers: []gardener.Worker{
@@ -0,0 +1,61 @@
+package runtime
+
+import (
+	"context"
+
+	gardener_api "github.com/gardener/gardener/pkg/apis/core/v1beta1"
+	imv1 "github.com/kyma-project/infrastructure-manager/api/v1"
+	"github.com/kyma-project/infrastructure-manager/pkg/gardener/shoot/extender"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+// mapShootToRuntime finds the Runtime owning the shoot using the runtime ID annotation set by the controller
+func (r *RuntimeReconciler) mapShootToRuntime(ctx context.Context, shoot *gardener_api.Shoot) []reconcile.Request {
+	runtimeID, found := shoot.GetAnnotations()[extender.ShootRuntimeIDAnnotation]
+	if !found || runtimeID == "" {
+		return nil
+	}
+
+	var runtimes imv1.RuntimeList
+	if err := r.List(ctx, &runtimes, client.MatchingLabels{imv1.LabelKymaRuntimeID: runtimeID}); err != nil {
+		r.Log.Error(err, "unable to list runtimes for shoot", "Name", shoot.Name, "RuntimeID", runtimeID)
+		return nil
+	}
+
+	requests := make([]reconcile.Request, 0, len(runtimes.Items))
+	for _, rt := range runtimes.Items {
+		requests = append(requests, reconcile.Request{
+			NamespacedName: types.NamespacedName{Name: rt.Name, Namespace: rt.Namespace},
+		})
+	}
+
+	return requests
+}
+
+// shootChangedPredicate passes the shoot events which can affect the state of the Runtime,
+// updates reporting only the progress of the current operation are filtered out
+func shootChangedPredicate() predicate.TypedPredicate[*gardener_api.Shoot] {
+	return predicate.TypedFuncs[*gardener_api.Shoot]{
+		UpdateFunc: func(e event.TypedUpdateEvent[*gardener_api.Shoot]) bool {
+			if e.ObjectOld == nil || e.ObjectNew == nil {
+				return false
+			}
+
+			if e.ObjectOld.GetGeneration() != e.ObjectNew.GetGeneration() {
+				return true
+			}
+
+			oldOperation := e.ObjectOld.Status.LastOperation
+			newOperation := e.ObjectNew.Status.LastOperation
+			if oldOperation == nil || newOperation == nil {
+				return oldOperation != newOperation
+			}
+
+			return oldOperation.Type != newOperation.Type || oldOperation.State != newOperation.State
+		},
+	}
+}
@@ -0,0 +1,152 @@
+package runtime
+
+import (
+	"context"
+	"testing"
+
+	gardener_api "github.com/gardener/gardener/pkg/apis/core/v1beta1"
+	imv1 "github.com/kyma-project/infrastructure-manager/api/v1"
+	"github.com/kyma-project/infrastructure-manager/pkg/gardener/shoot/extender"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+)
+
+func TestMapShootToRuntime(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, imv1.AddToScheme(scheme))
+
+	runtimeStub := imv1.Runtime{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "runtime-id",
+			Namespace: "kcp-system",
+			Labels: map[string]string{
+				imv1.LabelKymaRuntimeID: "runtime-id",
+			},
+		},
+	}
+
+	reconciler := &RuntimeReconciler{
+		Client: fake.NewClientBuilder().WithScheme(scheme).WithObjects(&runtimeStub).Build(),
+	}
+
+	t.Run("should map shoot to the runtime with matching runtime ID", func(t *testing.T) {
+		// given
+		shoot := &gardener_api.Shoot{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        "shoot",
+				Namespace:   "garden-project",
+				Annotations: map[string]string{extender.ShootRuntimeIDAnnotation: "runtime-id"},
+			},
+		}
+
+		// when
+		requests := reconciler.mapShootToRuntime(context.Background(), shoot)
+
+		// then
+		require.Len(t, requests, 1)
+		assert.Equal(t, types.NamespacedName{Name: "runtime-id", Namespace: "kcp-system"}, requests[0].NamespacedName)
+	})
+
+	t.Run("should ignore shoot without runtime ID annotation", func(t *testing.T) {
+		// given
+		shoot := &gardener_api.Shoot{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "shoot",
+				Namespace: "garden-project",
+			},
+		}
+
+		// when
+		requests := reconciler.mapShootToRuntime(context.Background(), shoot)
+
+		// then
+		assert.Empty(t, requests)
+	})
+
+	t.Run("should ignore shoot of unknown runtime", func(t *testing.T) {
+		// given
+		shoot := &gardener_api.Shoot{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        "shoot",
+				Namespace:   "garden-project",
+				Annotations: map[string]string{extender.ShootRuntimeIDAnnotation: "other-runtime-id"},
+			},
+		}
+
+		// when
+		requests := reconciler.mapShootToRuntime(context.Background(), shoot)
+
+		// then
+		assert.Empty(t, requests)
+	})
+}
+
+func TestShootChangedPredicate(t *testing.T) {
+	for _, testCase := range []struct {
+		name     string
+		oldShoot *gardener_api.Shoot
+		newShoot *gardener_api.Shoot
+		expected bool
+	}{
+		{
+			name:     "should pass generation change",
+			oldShoot: fixShootWithLastOperation(1, gardener_api.LastOperationTypeReconcile, gardener_api.LastOperationStateSucceeded),
+			newShoot: fixShootWithLastOperation(2, gardener_api.LastOperationTypeReconcile, gardener_api.LastOperationStateSucceeded),
+			expected: true,
+		},
+		{
+			name:     "should pass last operation state change",
+			oldShoot: fixShootWithLastOperation(1, gardener_api.LastOperationTypeCreate, gardener_api.LastOperationStateProcessing),
+			newShoot: fixShootWithLastOperation(1, gardener_api.LastOperationTypeCreate, gardener_api.LastOperationStateSucceeded),
+			expected: true,
+		},
+		{
+			name:     "should pass last operation type change",
+			oldShoot: fixShootWithLastOperation(1, gardener_api.LastOperationTypeCreate, gardener_api.LastOperationStateSucceeded),
+			newShoot: fixShootWithLastOperation(1, gardener_api.LastOperationTypeReconcile, gardener_api.LastOperationStateProcessing),
+			expected: true,
+		},
+		{
+			name:     "should pass first last operation",
+			oldShoot: &gardener_api.Shoot{},
+			newShoot: fixShootWithLastOperation(0, gardener_api.LastOperationTypeCreate, gardener_api.LastOperationStateProcessing),
+			expected: true,
+		},
+		{
+			name:     "should filter out progress update",
+			oldShoot: fixShootWithLastOperation(1, gardener_api.LastOperationTypeCreate, gardener_api.LastOperationStateProcessing),
+			newShoot: fixShootWithLastOperation(1, gardener_api.LastOperationTypeCreate, gardener_api.LastOperationStateProcessing),
+			expected: false,
+		},
+	} {
+		t.Run(testCase.name, func(t *testing.T) {
+			// when
+			result := shootChangedPredicate().Update(event.TypedUpdateEvent[*gardener_api.Shoot]{
+				ObjectOld: testCase.oldShoot,
+				ObjectNew: testCase.newShoot,
+			})
+
+			// then
+			assert.Equal(t, testCase.expected, result)
+		})
+	}
+}
+
+func fixShootWithLastOperation(generation int64, operationType gardener_api.LastOperationType, state gardener_api.LastOperationState) *gardener_api.Shoot {
+	return &gardener_api.Shoot{
+		ObjectMeta: metav1.ObjectMeta{
+			Generation: generation,
+		},
+		Status: gardener_api.ShootStatus{
+			LastOperation: &gardener_api.LastOperation{
+				Type:  operationType,
+				State: state,
+			},
+		},
+	}
+}
@@ -132,7 +132,7 @@ var _ = BeforeSuite(func() {
 
 	runtimeReconciler = NewRuntimeReconciler(mgr, gardenerTestClient, logger, fsmCfg)
 	Expect(runtimeReconciler).NotTo(BeNil())
-	err = runtimeReconciler.SetupWithManager(mgr)
+	err = runtimeReconciler.SetupWithManager(mgr, nil)
 	Expect(err).To(BeNil())
 
 	//+kubebuilder:scaffold:scheme
@@ -190,6 +190,8 @@ func setupGardenerClientWithSequence(shoots []*gardener_api.Shoot, seeds []*gard
 	_ = gardener_api.AddToScheme(clientScheme)
 
 	tracker := clienttesting.NewObjectTracker(clientScheme, serializer.NewCodecFactory(clientScheme).UniversalDecoder())
+	Expect(tracker.Add(fixCloudProfileForTests())).To(Succeed())
+
 	customTracker = NewCustomTracker(tracker, shoots, seeds)
 	gardenerTestClient = fake.NewClientBuilder().WithScheme(clientScheme).WithObjectTracker(customTracker).
 		WithInterceptorFuncs(interceptor.Funcs{Patch: func(ctx context.Context, clnt client.WithWatch, obj client.Object, patch client.Patch, opts ...client.PatchOption) error {
@@ -371,6 +373,24 @@ func setupSeedObjectOnCluster(client client.Client) error {
 	return client.Create(context.Background(), seed)
 }
 
+func fixCloudProfileForTests() *gardener_api.CloudProfile {
+	return &gardener_api.CloudProfile{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "aws",
+		},
+		Spec: gardener_api.CloudProfileSpec{
+			MachineImages: []gardener_api.MachineImage{
+				{
+					Name: "gardenlinux",
+					Versions: []gardener_api.MachineImageVersion{
+						{ExpirableVersion: gardener_api.ExpirableVersion{Version: "1312.3.0"}},
+					},
+				},
+			},
+		},
+	}
+}
+
 func fixConverterConfigForTests() config.Config {
 	return config.Config{
 		ConverterConfig: config.ConverterConfig{
@@ -388,6 +408,10 @@ func fixConverterConfigForTests() config.Config {
 					EnableIMDSv2: true,
 				},
 			},
+			MachineImage: config.MachineImageConfig{
+				DefaultName:    "gardenlinux",
+				DefaultVersion: "1312.3.0",
+			},
 			Gardener: config.GardenerConfig{
 				ProjectName: "kyma-dev",
 			},
@@ -0,0 +1,63 @@
+package runtime
+
+import (
+	"context"
+
+	"github.com/kyma-project/infrastructure-manager/pkg/config"
+	"github.com/kyma-project/infrastructure-manager/pkg/gardener/shoot/extender"
+	admissionv1 "k8s.io/api/admission/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+//+kubebuilder:webhook:path=/mutate-infrastructuremanager-kyma-project-io-v1-runtime,mutating=true,failurePolicy=fail,sideEffects=None,groups=infrastructuremanager.kyma-project.io,resources=runtimes,verbs=create;update,versions=v1,name=mruntime.infrastructuremanager.kyma-project.io,admissionReviewVersions=v1
+
+// RuntimeDefaulter writes the defaults from the converter configuration into the Runtime CR,
+// so that the CR reflects what is requested from Gardener and later changes of the defaults do not alter existing clusters
+// nolint:revive
+type RuntimeDefaulter struct {
+	cfg config.Config
+}
+
+var _ webhook.CustomDefaulter = &RuntimeDefaulter{}
+
+func NewRuntimeDefaulter(cfg config.Config) *RuntimeDefaulter {
+	return &RuntimeDefaulter{
+		cfg: cfg,
+	}
+}
+
+func (d *RuntimeDefaulter) Default(ctx context.Context, obj runtime.Object) error {
+	rt, err := toRuntime(obj)
+	if err != nil {
+		return err
+	}
+
+	// the Runtime adopting the existing shoot is back-filled from the shoot, the defaults would not match it
+	if !rt.GetDeletionTimestamp().IsZero() || rt.IsMarkedForAdoption() {
+		return nil
+	}
+
+	converterConfig := d.cfg.ConverterConfig
+	rt.Spec.Shoot.Kubernetes.Version = ptr.To(extender.KubernetesVersionOrDefault(*rt, converterConfig.Kubernetes.DefaultVersion))
+	// only the pinned machine image version is written, otherwise the latest version is resolved from the cloud profile during the conversion
+	extender.SetDefaultMachineImage(rt.Spec.Shoot.Provider.Workers, converterConfig.MachineImage.DefaultName, converterConfig.MachineImage.PinnedVersion)
+
+	// the zone layout is chosen only for the new Runtimes, the subnets of the existing shoots cannot be moved
+	if zoneBits, found := converterConfig.Provider.ZoneBits[rt.Spec.Shoot.Provider.Type]; found && isCreateRequest(ctx) && rt.Spec.Shoot.Networking.ZoneBits == nil {
+		rt.Spec.Shoot.Networking.ZoneBits = ptr.To(zoneBits)
+	}
+
+	if extender.CanEnableExtension(*rt) {
+		extender.DefaultAdditionalOidcIfNotPresent(rt, d.cfg.ClusterConfig.DefaultSharedIASTenant)
+	}
+
+	return nil
+}
+
+func isCreateRequest(ctx context.Context) bool {
+	req, err := admission.RequestFromContext(ctx)
+	return err == nil && req.Operation == admissionv1.Create
+}
@@ -0,0 +1,163 @@
+package runtime
+
+import (
+	"context"
+	"testing"
+
+	gardener "github.com/gardener/gardener/pkg/apis/core/v1beta1"
+	imv1 "github.com/kyma-project/infrastructure-manager/api/v1"
+	"github.com/kyma-project/infrastructure-manager/pkg/config"
+	"github.com/kyma-project/infrastructure-manager/pkg/gardener/shoot/hyperscaler"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	admissionv1 "k8s.io/api/admission/v1"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+func TestRuntimeDefaulter(t *testing.T) {
+	t.Run("Write converter defaults into Runtime", func(t *testing.T) {
+		// given
+		defaulter := NewRuntimeDefaulter(fixConfig())
+		runtime := fixRuntime()
+
+		// when
+		err := defaulter.Default(context.Background(), &runtime)
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, ptr.To("1.29"), runtime.Spec.Shoot.Kubernetes.Version)
+
+		image := runtime.Spec.Shoot.Provider.Workers[0].Machine.Image
+		require.NotNil(t, image)
+		assert.Equal(t, "gardenlinux", image.Name)
+		assert.Nil(t, image.Version)
+
+		additionalOidcConfig := runtime.Spec.Shoot.Kubernetes.KubeAPIServer.AdditionalOidcConfig
+		require.NotNil(t, additionalOidcConfig)
+		require.Len(t, *additionalOidcConfig, 1)
+		assert.Equal(t, ptr.To("shared-client-id"), (*additionalOidcConfig)[0].ClientID)
+		assert.Equal(t, ptr.To("https://shared.ias.com"), (*additionalOidcConfig)[0].IssuerURL)
+	})
+
+	t.Run("Write pinned machine image version into Runtime", func(t *testing.T) {
+		// given
+		cfg := fixConfig()
+		cfg.ConverterConfig.MachineImage.PinnedVersion = "1443.10.0"
+		defaulter := NewRuntimeDefaulter(cfg)
+		runtime := fixRuntime()
+
+		// when
+		err := defaulter.Default(context.Background(), &runtime)
+
+		// then
+		require.NoError(t, err)
+
+		image := runtime.Spec.Shoot.Provider.Workers[0].Machine.Image
+		require.NotNil(t, image)
+		assert.Equal(t, "gardenlinux", image.Name)
+		assert.Equal(t, ptr.To("1443.10.0"), image.Version)
+	})
+
+	t.Run("Keep values specified in Runtime", func(t *testing.T) {
+		// given
+		defaulter := NewRuntimeDefaulter(fixConfig())
+		runtime := fixRuntime()
+		runtime.Spec.Shoot.Kubernetes.Version = ptr.To("1.30")
+		runtime.Spec.Shoot.Provider.Workers[0].Machine.Image = &gardener.ShootMachineImage{
+			Name:    "ubuntu",
+			Version: ptr.To("18.04"),
+		}
+		runtime.Spec.Shoot.Kubernetes.KubeAPIServer.AdditionalOidcConfig = &[]gardener.OIDCConfig{}
+
+		// when
+		err := defaulter.Default(context.Background(), &runtime)
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, ptr.To("1.30"), runtime.Spec.Shoot.Kubernetes.Version)
+		assert.Equal(t, "ubuntu", runtime.Spec.Shoot.Provider.Workers[0].Machine.Image.Name)
+		assert.Equal(t, ptr.To("18.04"), runtime.Spec.Shoot.Provider.Workers[0].Machine.Image.Version)
+		assert.Empty(t, *runtime.Spec.Shoot.Kubernetes.KubeAPIServer.AdditionalOidcConfig)
+	})
+
+	t.Run("Write zone layout into Runtime only on creation", func(t *testing.T) {
+		// given
+		cfg := fixConfig()
+		cfg.ConverterConfig.Provider.ZoneBits = map[string]int{hyperscaler.TypeAWS: 3}
+		defaulter := NewRuntimeDefaulter(cfg)
+		created := fixRuntime()
+		updated := fixRuntime()
+
+		// when
+		err := defaulter.Default(admission.NewContextWithRequest(context.Background(), fixAdmissionRequest(admissionv1.Create)), &created)
+		require.NoError(t, err)
+		err = defaulter.Default(admission.NewContextWithRequest(context.Background(), fixAdmissionRequest(admissionv1.Update)), &updated)
+		require.NoError(t, err)
+
+		// then
+		assert.Equal(t, ptr.To(3), created.Spec.Shoot.Networking.ZoneBits)
+		assert.Nil(t, updated.Spec.Shoot.Networking.ZoneBits)
+	})
+
+	t.Run("Do not default additional OIDC config for runtimes created by migrator", func(t *testing.T) {
+		// given
+		defaulter := NewRuntimeDefaulter(fixConfig())
+		runtime := fixRuntime()
+		runtime.Labels["operator.kyma-project.io/created-by-migrator"] = "true"
+
+		// when
+		err := defaulter.Default(context.Background(), &runtime)
+
+		// then
+		require.NoError(t, err)
+		assert.Nil(t, runtime.Spec.Shoot.Kubernetes.KubeAPIServer.AdditionalOidcConfig)
+	})
+
+	t.Run("Do not write defaults into Runtime adopting existing shoot", func(t *testing.T) {
+		// given
+		defaulter := NewRuntimeDefaulter(fixConfig())
+		runtime := fixRuntime()
+		runtime.Annotations = map[string]string{imv1.AnnotationAdopt: "true"}
+
+		// when
+		err := defaulter.Default(context.Background(), &runtime)
+
+		// then
+		require.NoError(t, err)
+		assert.Nil(t, runtime.Spec.Shoot.Kubernetes.Version)
+		assert.Nil(t, runtime.Spec.Shoot.Kubernetes.KubeAPIServer.AdditionalOidcConfig)
+	})
+}
+
+func fixAdmissionRequest(operation admissionv1.Operation) admission.Request {
+	return admission.Request{AdmissionRequest: admissionv1.AdmissionRequest{Operation: operation}}
+}
+
+func fixConfig() config.Config {
+	return config.Config{
+		ConverterConfig: config.ConverterConfig{
+			Kubernetes: config.KubernetesConfig{
+				DefaultVersion: "1.29",
+			},
+			MachineImage: config.MachineImageConfig{
+				DefaultName:    "gardenlinux",
+				DefaultVersion: "1312.3.0",
+			},
+			Gardener: config.GardenerConfig{
+				ProjectName:            "kyma-dev",
+				AdditionalProjectNames: []string{"kyma-additional"},
+			},
+		},
+		ClusterConfig: config.ClusterConfig{
+			DefaultSharedIASTenant: config.OidcProvider{
+				ClientID:       "shared-client-id",
+				GroupsClaim:    "groups",
+				IssuerURL:      "https://shared.ias.com",
+				SigningAlgs:    []string{"RS256"},
+				UsernameClaim:  "sub",
+				UsernamePrefix: "-",
+			},
+		},
+	}
+}
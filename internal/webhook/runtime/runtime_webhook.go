@@ -0,0 +1,452 @@
+package runtime
+
+import (
+	"context"
+	"fmt"
+	"net/netip"
+	"slices"
+	"strconv"
+	"time"
+
+	gardener "github.com/gardener/gardener/pkg/apis/core/v1beta1"
+	"github.com/gardener/gardener/pkg/utils/timewindow"
+	imv1 "github.com/kyma-project/infrastructure-manager/api/v1"
+	"github.com/kyma-project/infrastructure-manager/pkg/config"
+	"github.com/kyma-project/infrastructure-manager/pkg/gardener/shoot/extender"
+	"github.com/kyma-project/infrastructure-manager/pkg/gardener/shoot/hyperscaler"
+	"github.com/kyma-project/infrastructure-manager/pkg/gardener/shoot/hyperscaler/alicloud"
+	"github.com/kyma-project/infrastructure-manager/pkg/gardener/shoot/hyperscaler/aws"
+	"github.com/kyma-project/infrastructure-manager/pkg/gardener/shoot/hyperscaler/azure"
+	"github.com/kyma-project/infrastructure-manager/pkg/gardener/shoot/hyperscaler/gcp"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+//+kubebuilder:webhook:path=/validate-infrastructuremanager-kyma-project-io-v1-runtime,mutating=false,failurePolicy=fail,sideEffects=None,groups=infrastructuremanager.kyma-project.io,resources=runtimes,verbs=create;update;delete,versions=v1,name=vruntime.infrastructuremanager.kyma-project.io,admissionReviewVersions=v1
+
+// RuntimeValidator rejects Runtime CRs which would fail later on during the shoot conversion
+// nolint:revive
+type RuntimeValidator struct {
+	projectNames []string
+}
+
+var _ webhook.CustomValidator = &RuntimeValidator{}
+
+func NewRuntimeValidator(cfg config.Config) *RuntimeValidator {
+	return &RuntimeValidator{
+		projectNames: cfg.ConverterConfig.Gardener.ProjectNames(),
+	}
+}
+
+func SetupRuntimeWebhookWithManager(mgr ctrl.Manager, cfg config.Config) error {
+	return ctrl.NewWebhookManagedBy(mgr).
+		For(&imv1.Runtime{}).
+		WithDefaulter(NewRuntimeDefaulter(cfg)).
+		WithValidator(NewRuntimeValidator(cfg)).
+		Complete()
+}
+
+func (v *RuntimeValidator) ValidateCreate(_ context.Context, obj runtime.Object) (admission.Warnings, error) {
+	rt, err := toRuntime(obj)
+	if err != nil {
+		return nil, err
+	}
+
+	return nil, toInvalidError(rt, v.validateRuntime(rt))
+}
+
+func (v *RuntimeValidator) ValidateUpdate(_ context.Context, _, newObj runtime.Object) (admission.Warnings, error) {
+	rt, err := toRuntime(newObj)
+	if err != nil {
+		return nil, err
+	}
+
+	// the runtime is being deleted, updates (e.g. finalizer removal) must not be blocked
+	if !rt.GetDeletionTimestamp().IsZero() {
+		return nil, nil
+	}
+
+	return nil, toInvalidError(rt, v.validateRuntime(rt))
+}
+
+func (v *RuntimeValidator) ValidateDelete(_ context.Context, obj runtime.Object) (admission.Warnings, error) {
+	rt, err := toRuntime(obj)
+	if err != nil {
+		return nil, err
+	}
+
+	if rt.IsDeletionProtected() {
+		return nil, apierrors.NewForbidden(
+			imv1.GroupVersion.WithResource("runtimes").GroupResource(),
+			rt.Name,
+			fmt.Errorf("runtime is protected against deletion, remove the %s annotation first", imv1.AnnotationDeletionProtection),
+		)
+	}
+
+	return nil, nil
+}
+
+func toRuntime(obj runtime.Object) (*imv1.Runtime, error) {
+	rt, ok := obj.(*imv1.Runtime)
+	if !ok {
+		return nil, fmt.Errorf("expected a Runtime but got a %T", obj)
+	}
+	return rt, nil
+}
+
+func toInvalidError(rt *imv1.Runtime, allErrs field.ErrorList) error {
+	if len(allErrs) == 0 {
+		return nil
+	}
+	return apierrors.NewInvalid(imv1.GroupVersion.WithKind("Runtime").GroupKind(), rt.Name, allErrs)
+}
+
+func (v *RuntimeValidator) validateRuntime(rt *imv1.Runtime) field.ErrorList {
+	var allErrs field.ErrorList
+
+	if err := rt.ValidateRequiredLabels(); err != nil {
+		allErrs = append(allErrs, field.Required(field.NewPath("metadata", "labels"), err.Error()))
+	}
+
+	shootPath := field.NewPath("spec", "shoot")
+	allErrs = append(allErrs, validateProjectName(rt.Spec.Shoot.ProjectName, v.projectNames, shootPath.Child("projectName"))...)
+	// the workers of the Runtime adopting the existing shoot are back-filled from the shoot, they are validated once set
+	if !rt.IsMarkedForAdoption() || len(rt.Spec.Shoot.Provider.Workers) > 0 {
+		allErrs = append(allErrs, validateProvider(rt.Spec.Shoot.Provider, shootPath.Child("provider"))...)
+		allErrs = append(allErrs, validateWorkerConfigs(rt.Spec.Shoot.Provider, shootPath.Child("provider", "workers"))...)
+		allErrs = append(allErrs, validateWorkers(rt.Spec.Shoot.Provider.Workers, shootPath.Child("provider", "workers"))...)
+		allErrs = append(allErrs, validateProviderConfig(rt.Spec.Shoot.Provider, rt.Spec.Shoot.Networking, shootPath.Child("provider"))...)
+		allErrs = append(allErrs, validateWorkersCIDR(rt.Spec.Shoot.Provider, rt.Spec.Shoot.Networking, shootPath.Child("networking", "nodes"))...)
+	}
+	allErrs = append(allErrs, validateNetworking(rt.Spec.Shoot.Networking, shootPath.Child("networking"))...)
+	allErrs = append(allErrs, validateHibernation(rt.Spec.Shoot.Hibernation, shootPath.Child("hibernation"))...)
+	allErrs = append(allErrs, validateMaintenance(rt.Spec.Shoot.Maintenance, shootPath.Child("maintenance"))...)
+	allErrs = append(allErrs, validateDeletion(rt.Spec.Deletion, field.NewPath("spec", "deletion"))...)
+
+	return allErrs
+}
+
+func validateProjectName(projectName string, projectNames []string, path *field.Path) field.ErrorList {
+	if projectName == "" || slices.Contains(projectNames, projectName) {
+		return nil
+	}
+
+	return field.ErrorList{field.NotSupported(path, projectName, projectNames)}
+}
+
+func validateProvider(provider imv1.Provider, path *field.Path) field.ErrorList {
+	if !hyperscaler.IsSupported(provider.Type) {
+		return field.ErrorList{field.NotSupported(path.Child("type"), provider.Type, hyperscaler.SupportedTypes())}
+	}
+
+	var allErrs field.ErrorList
+
+	switch provider.Type {
+	case hyperscaler.TypeAzure:
+		for i, worker := range provider.Workers {
+			if err := azure.ValidateZoneNames(worker.Zones); err != nil {
+				allErrs = append(allErrs, field.Invalid(path.Child("workers").Index(i).Child("zones"), worker.Zones, err.Error()))
+			}
+		}
+	case hyperscaler.TypeGCP:
+		var zones []string
+		for _, worker := range provider.Workers {
+			zones = append(zones, worker.Zones...)
+		}
+
+		if err := gcp.ValidateZones(zones); err != nil {
+			allErrs = append(allErrs, field.Invalid(path.Child("workers"), zones, err.Error()))
+		}
+	}
+
+	return allErrs
+}
+
+// validateWorkerConfigs checks the provider configs of the workers which are merged with the defaults during the shoot conversion
+func validateWorkerConfigs(provider imv1.Provider, path *field.Path) field.ErrorList {
+	var validateWorkerConfig func([]byte) error
+	switch provider.Type {
+	case hyperscaler.TypeAWS:
+		validateWorkerConfig = aws.ValidateWorkerConfig
+	case hyperscaler.TypeAzure:
+		validateWorkerConfig = azure.ValidateWorkerConfig
+	case hyperscaler.TypeGCP:
+		validateWorkerConfig = gcp.ValidateWorkerConfig
+	default:
+		return nil
+	}
+
+	var allErrs field.ErrorList
+
+	for i, worker := range provider.Workers {
+		if worker.ProviderConfig == nil || len(worker.ProviderConfig.Raw) == 0 {
+			continue
+		}
+
+		if err := validateWorkerConfig(worker.ProviderConfig.Raw); err != nil {
+			allErrs = append(allErrs, field.Invalid(path.Index(i).Child("providerConfig"), string(worker.ProviderConfig.Raw), err.Error()))
+		}
+	}
+
+	return allErrs
+}
+
+// validateWorkers checks the scaling and the rolling update settings of the worker pools,
+// the defaults from the converter config are validated once again during the shoot conversion
+func validateWorkers(workers []gardener.Worker, path *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+
+	for i, worker := range workers {
+		if err := extender.ValidateWorker(worker); err != nil {
+			allErrs = append(allErrs, field.Invalid(path.Index(i), worker.Name, err.Error()))
+		}
+	}
+
+	return allErrs
+}
+
+// validateWorkersCIDR checks that the nodes CIDR can be split into the subnets of all the zones
+func validateWorkersCIDR(provider imv1.Provider, networking imv1.Networking, path *field.Path) field.ErrorList {
+	nodes := networking.Nodes
+	// the nodes CIDR is validated on its own in validateNetworking
+	if _, err := netip.ParsePrefix(nodes); err != nil {
+		return nil
+	}
+
+	zones := workerZones(provider.Workers)
+	zoneBits := networking.ZoneBits
+
+	var err error
+	switch provider.Type {
+	case hyperscaler.TypeAWS:
+		err = aws.ValidateWorkersCIDR(nodes, zones, zoneBits)
+	case hyperscaler.TypeAzure:
+		err = azure.ValidateWorkersCIDR(nodes, zones, zoneBits)
+	case hyperscaler.TypeAlicloud:
+		err = alicloud.ValidateWorkersCIDR(nodes, zones, zoneBits)
+	}
+
+	if err != nil {
+		return field.ErrorList{field.Invalid(path, nodes, err.Error())}
+	}
+
+	return nil
+}
+
+// validateProviderConfig checks the provider specific settings, only the section of the provider type is allowed
+func validateProviderConfig(provider imv1.Provider, networking imv1.Networking, path *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+
+	for _, section := range []struct {
+		name         string
+		providerType string
+		set          bool
+	}{
+		{name: "aws", providerType: hyperscaler.TypeAWS, set: provider.AWS != nil},
+		{name: "azure", providerType: hyperscaler.TypeAzure, set: provider.Azure != nil},
+		{name: "gcp", providerType: hyperscaler.TypeGCP, set: provider.GCP != nil},
+		{name: "openstack", providerType: hyperscaler.TypeOpenStack, set: provider.OpenStack != nil},
+	} {
+		if section.set && provider.Type != section.providerType {
+			allErrs = append(allErrs, field.Forbidden(path.Child(section.name), fmt.Sprintf("not allowed for provider type %s", provider.Type)))
+		}
+	}
+
+	if len(allErrs) > 0 {
+		return allErrs
+	}
+
+	switch {
+	case provider.AWS != nil:
+		allErrs = append(allErrs, validateAWSProviderConfig(*provider.AWS, path.Child("aws"))...)
+	case provider.Azure != nil:
+		allErrs = append(allErrs, validateAzureProviderConfig(*provider.Azure, provider.Workers, path.Child("azure"))...)
+	case provider.GCP != nil:
+		allErrs = append(allErrs, validateGCPProviderConfig(*provider.GCP, provider.Workers, networking, path.Child("gcp"))...)
+	}
+
+	return allErrs
+}
+
+func validateAWSProviderConfig(awsConfig imv1.AWSProviderConfig, path *field.Path) field.ErrorList {
+	if awsConfig.VPCID != nil && *awsConfig.VPCID == "" {
+		return field.ErrorList{field.Required(path.Child("vpcID"), "must not be empty")}
+	}
+
+	return nil
+}
+
+func validateAzureProviderConfig(azureConfig imv1.AzureProviderConfig, workers []gardener.Worker, path *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+
+	if vnet := azureConfig.VNet; vnet != nil {
+		vnetPath := path.Child("vnet")
+		if vnet.Name == "" {
+			allErrs = append(allErrs, field.Required(vnetPath.Child("name"), "must not be empty"))
+		}
+		if vnet.ResourceGroup == "" {
+			allErrs = append(allErrs, field.Required(vnetPath.Child("resourceGroup"), "must not be empty"))
+		}
+	}
+
+	if azureConfig.NatGateway != nil {
+		allErrs = append(allErrs, validateAzureNatGateway(*azureConfig.NatGateway, workers, path.Child("natGateway"))...)
+	}
+
+	return allErrs
+}
+
+func validateAzureNatGateway(natGateway imv1.AzureNatGateway, workers []gardener.Worker, path *field.Path) field.ErrorList {
+	if len(natGateway.IPAddresses) == 0 {
+		return nil
+	}
+
+	ipAddressesPath := path.Child("ipAddresses")
+	if natGateway.Enabled != nil && !*natGateway.Enabled {
+		return field.ErrorList{field.Forbidden(ipAddressesPath, "not allowed when the NAT gateway is disabled")}
+	}
+
+	var allErrs field.ErrorList
+
+	zones := workerZones(workers)
+	for i, ipAddress := range natGateway.IPAddresses {
+		if zone := strconv.Itoa(int(ipAddress.Zone)); !slices.Contains(zones, zone) {
+			allErrs = append(allErrs, field.Invalid(ipAddressesPath.Index(i).Child("zone"), ipAddress.Zone, "must be one of the worker zones"))
+		}
+	}
+
+	return allErrs
+}
+
+func validateGCPProviderConfig(gcpConfig imv1.GCPProviderConfig, workers []gardener.Worker, networking imv1.Networking, path *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+
+	if zone := gcpConfig.ControlPlaneZone; zone != nil {
+		if err := gcp.ValidateControlPlaneZone(*zone, workerZones(workers)); err != nil {
+			allErrs = append(allErrs, field.Invalid(path.Child("controlPlaneZone"), *zone, err.Error()))
+		}
+	}
+
+	if internal := gcpConfig.Internal; internal != nil {
+		internalPath := path.Child("internal")
+		if prefix, err := netip.ParsePrefix(*internal); err != nil {
+			allErrs = append(allErrs, field.Invalid(internalPath, *internal, "must be a valid CIDR"))
+		} else {
+			for _, cidr := range []struct{ name, value string }{
+				{name: "nodes", value: networking.Nodes},
+				{name: "pods", value: networking.Pods},
+				{name: "services", value: networking.Services},
+			} {
+				if other, err := netip.ParsePrefix(cidr.value); err == nil && prefix.Overlaps(other) {
+					allErrs = append(allErrs, field.Invalid(internalPath, *internal, fmt.Sprintf("overlaps with %s CIDR %s", cidr.name, cidr.value)))
+				}
+			}
+		}
+	}
+
+	if cloudNAT := gcpConfig.CloudNAT; cloudNAT != nil {
+		for i, natIPName := range cloudNAT.NatIPNames {
+			if natIPName == "" {
+				allErrs = append(allErrs, field.Required(path.Child("cloudNAT", "natIPNames").Index(i), "must not be empty"))
+			}
+		}
+	}
+
+	return allErrs
+}
+
+// workerZones returns the sorted zones of all the workers without duplicates
+func workerZones(workers []gardener.Worker) []string {
+	var zones []string
+	for _, worker := range workers {
+		zones = append(zones, worker.Zones...)
+	}
+	slices.Sort(zones)
+
+	return slices.Compact(zones)
+}
+
+func validateHibernation(hibernation *gardener.Hibernation, path *field.Path) field.ErrorList {
+	if hibernation == nil {
+		return nil
+	}
+
+	var allErrs field.ErrorList
+
+	for i, schedule := range hibernation.Schedules {
+		schedulePath := path.Child("schedules").Index(i)
+
+		if schedule.Start == nil && schedule.End == nil {
+			allErrs = append(allErrs, field.Required(schedulePath, "either start or end must be specified"))
+		}
+
+		if schedule.Location != nil {
+			if _, err := time.LoadLocation(*schedule.Location); err != nil {
+				allErrs = append(allErrs, field.Invalid(schedulePath.Child("location"), *schedule.Location, "must be a valid time zone"))
+			}
+		}
+	}
+
+	return allErrs
+}
+
+func validateMaintenance(maintenance *imv1.Maintenance, path *field.Path) field.ErrorList {
+	if maintenance == nil || maintenance.TimeWindow == nil {
+		return nil
+	}
+
+	timeWindow := maintenance.TimeWindow
+	if _, err := timewindow.ParseMaintenanceTimeWindow(timeWindow.Begin, timeWindow.End); err != nil {
+		return field.ErrorList{field.Invalid(path.Child("timeWindow"), *timeWindow, err.Error())}
+	}
+
+	return nil
+}
+
+func validateNetworking(networking imv1.Networking, path *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+
+	type namedCIDR struct {
+		name   string
+		value  string
+		prefix netip.Prefix
+	}
+
+	var cidrs []namedCIDR
+	for _, cidr := range []namedCIDR{
+		{name: "nodes", value: networking.Nodes},
+		{name: "pods", value: networking.Pods},
+		{name: "services", value: networking.Services},
+	} {
+		prefix, err := netip.ParsePrefix(cidr.value)
+		if err != nil {
+			allErrs = append(allErrs, field.Invalid(path.Child(cidr.name), cidr.value, "must be a valid CIDR"))
+			continue
+		}
+		cidr.prefix = prefix
+		cidrs = append(cidrs, cidr)
+	}
+
+	for i := 0; i < len(cidrs); i++ {
+		for j := i + 1; j < len(cidrs); j++ {
+			if cidrs[i].prefix.Overlaps(cidrs[j].prefix) {
+				msg := fmt.Sprintf("overlaps with %s CIDR %s", cidrs[i].name, cidrs[i].value)
+				allErrs = append(allErrs, field.Invalid(path.Child(cidrs[j].name), cidrs[j].value, msg))
+			}
+		}
+	}
+
+	return allErrs
+}
+
+func validateDeletion(deletion *imv1.Deletion, path *field.Path) field.ErrorList {
+	if deletion == nil || !deletion.KeepGardenerCluster || deletion.Policy == imv1.DeletionPolicyOrphan {
+		return nil
+	}
+
+	return field.ErrorList{field.Forbidden(path.Child("keepGardenerCluster"), "can be set only with the Orphan deletion policy")}
+}
@@ -0,0 +1,443 @@
+package runtime
+
+import (
+	"context"
+	"testing"
+
+	gardener "github.com/gardener/gardener/pkg/apis/core/v1beta1"
+	imv1 "github.com/kyma-project/infrastructure-manager/api/v1"
+	"github.com/kyma-project/infrastructure-manager/pkg/gardener/shoot/hyperscaler"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/utils/ptr"
+)
+
+func TestRuntimeValidator(t *testing.T) {
+	for tname, testCase := range map[string]struct {
+		modify        func(*imv1.Runtime)
+		expectedError string
+	}{
+		"Accept valid AWS runtime": {
+			modify: func(_ *imv1.Runtime) {},
+		},
+		"Reject runtime with missing required label": {
+			modify: func(rt *imv1.Runtime) {
+				delete(rt.Labels, imv1.LabelKymaGlobalAccountID)
+			},
+			expectedError: "metadata.labels",
+		},
+		"Reject unsupported provider type": {
+			modify: func(rt *imv1.Runtime) {
+				rt.Spec.Shoot.Provider.Type = "unknown"
+			},
+			expectedError: "spec.shoot.provider.type",
+		},
+		"Reject overlapping nodes and pods CIDRs": {
+			modify: func(rt *imv1.Runtime) {
+				rt.Spec.Shoot.Networking.Pods = "10.250.0.0/24"
+			},
+			expectedError: "spec.shoot.networking.pods",
+		},
+		"Reject invalid services CIDR": {
+			modify: func(rt *imv1.Runtime) {
+				rt.Spec.Shoot.Networking.Services = "not-a-cidr"
+			},
+			expectedError: "spec.shoot.networking.services",
+		},
+		"Accept valid Azure runtime": {
+			modify: func(rt *imv1.Runtime) {
+				rt.Spec.Shoot.Provider.Type = hyperscaler.TypeAzure
+				rt.Spec.Shoot.Provider.Workers[0].Zones = []string{"1", "2", "3"}
+			},
+		},
+		"Reject Azure worker without zones": {
+			modify: func(rt *imv1.Runtime) {
+				rt.Spec.Shoot.Provider.Type = hyperscaler.TypeAzure
+				rt.Spec.Shoot.Provider.Workers[0].Zones = nil
+			},
+			expectedError: "spec.shoot.provider.workers[0].zones",
+		},
+		"Reject Azure worker with invalid zone name": {
+			modify: func(rt *imv1.Runtime) {
+				rt.Spec.Shoot.Provider.Type = hyperscaler.TypeAzure
+				rt.Spec.Shoot.Provider.Workers[0].Zones = []string{"1", "westeurope-4"}
+			},
+			expectedError: "spec.shoot.provider.workers[0].zones",
+		},
+		"Reject GCP runtime without zones": {
+			modify: func(rt *imv1.Runtime) {
+				rt.Spec.Shoot.Provider.Type = hyperscaler.TypeGCP
+				rt.Spec.Shoot.Provider.Workers[0].Zones = []string{}
+			},
+			expectedError: "spec.shoot.provider.workers",
+		},
+		"Accept AWS runtime with existing VPC": {
+			modify: func(rt *imv1.Runtime) {
+				rt.Spec.Shoot.Provider.AWS = &imv1.AWSProviderConfig{VPCID: ptr.To("vpc-123456")}
+			},
+		},
+		"Reject AWS runtime with existing VPC and too small nodes CIDR": {
+			modify: func(rt *imv1.Runtime) {
+				rt.Spec.Shoot.Provider.AWS = &imv1.AWSProviderConfig{VPCID: ptr.To("vpc-123456")}
+				rt.Spec.Shoot.Networking.Nodes = "10.250.0.0/29"
+			},
+			expectedError: "spec.shoot.networking.nodes",
+		},
+		"Reject AWS runtime with existing VPC and too many zones": {
+			modify: func(rt *imv1.Runtime) {
+				rt.Spec.Shoot.Provider.AWS = &imv1.AWSProviderConfig{VPCID: ptr.To("vpc-123456")}
+				rt.Spec.Shoot.Provider.Workers[0].Zones = []string{"eu-central-1a", "eu-central-1b", "eu-central-1c", "eu-central-1d", "eu-central-1e"}
+			},
+			expectedError: "spec.shoot.networking.nodes",
+		},
+		"Reject AWS runtime with empty VPC ID": {
+			modify: func(rt *imv1.Runtime) {
+				rt.Spec.Shoot.Provider.AWS = &imv1.AWSProviderConfig{VPCID: ptr.To("")}
+			},
+			expectedError: "spec.shoot.provider.aws.vpcID",
+		},
+		"Accept Azure runtime with existing VNet": {
+			modify: func(rt *imv1.Runtime) {
+				rt.Spec.Shoot.Provider.Type = hyperscaler.TypeAzure
+				rt.Spec.Shoot.Provider.Workers[0].Zones = []string{"1", "2", "3"}
+				rt.Spec.Shoot.Provider.Azure = &imv1.AzureProviderConfig{
+					VNet: &imv1.AzureVNet{Name: "vnet", ResourceGroup: "vnet-rg"},
+				}
+			},
+		},
+		"Reject Azure runtime with existing VNet without resource group": {
+			modify: func(rt *imv1.Runtime) {
+				rt.Spec.Shoot.Provider.Type = hyperscaler.TypeAzure
+				rt.Spec.Shoot.Provider.Workers[0].Zones = []string{"1", "2", "3"}
+				rt.Spec.Shoot.Provider.Azure = &imv1.AzureProviderConfig{
+					VNet: &imv1.AzureVNet{Name: "vnet"},
+				}
+			},
+			expectedError: "spec.shoot.provider.azure.vnet.resourceGroup",
+		},
+		"Accept Azure runtime with NAT gateway public IPs": {
+			modify: func(rt *imv1.Runtime) {
+				rt.Spec.Shoot.Provider.Type = hyperscaler.TypeAzure
+				rt.Spec.Shoot.Provider.Workers[0].Zones = []string{"1", "2", "3"}
+				rt.Spec.Shoot.Provider.Azure = &imv1.AzureProviderConfig{
+					NatGateway: &imv1.AzureNatGateway{
+						IdleConnectionTimeoutMinutes: ptr.To(30),
+						IPAddresses:                  []imv1.AzurePublicIPReference{{Name: "ip", ResourceGroup: "ip-rg", Zone: 2}},
+					},
+				}
+			},
+		},
+		"Reject NAT gateway public IP outside of the worker zones": {
+			modify: func(rt *imv1.Runtime) {
+				rt.Spec.Shoot.Provider.Type = hyperscaler.TypeAzure
+				rt.Spec.Shoot.Provider.Workers[0].Zones = []string{"1", "2"}
+				rt.Spec.Shoot.Provider.Azure = &imv1.AzureProviderConfig{
+					NatGateway: &imv1.AzureNatGateway{
+						IPAddresses: []imv1.AzurePublicIPReference{{Name: "ip", ResourceGroup: "ip-rg", Zone: 3}},
+					},
+				}
+			},
+			expectedError: "spec.shoot.provider.azure.natGateway.ipAddresses[0].zone",
+		},
+		"Reject NAT gateway public IPs when NAT gateway is disabled": {
+			modify: func(rt *imv1.Runtime) {
+				rt.Spec.Shoot.Provider.Type = hyperscaler.TypeAzure
+				rt.Spec.Shoot.Provider.Workers[0].Zones = []string{"1", "2", "3"}
+				rt.Spec.Shoot.Provider.Azure = &imv1.AzureProviderConfig{
+					NatGateway: &imv1.AzureNatGateway{
+						Enabled:     ptr.To(false),
+						IPAddresses: []imv1.AzurePublicIPReference{{Name: "ip", ResourceGroup: "ip-rg", Zone: 1}},
+					},
+				}
+			},
+			expectedError: "spec.shoot.provider.azure.natGateway.ipAddresses",
+		},
+		"Reject Azure settings for AWS runtime": {
+			modify: func(rt *imv1.Runtime) {
+				rt.Spec.Shoot.Provider.Azure = &imv1.AzureProviderConfig{
+					VNet: &imv1.AzureVNet{Name: "vnet", ResourceGroup: "vnet-rg"},
+				}
+			},
+			expectedError: "spec.shoot.provider.azure",
+		},
+		"Accept GCP runtime with networking options": {
+			modify: func(rt *imv1.Runtime) {
+				rt.Spec.Shoot.Provider.Type = hyperscaler.TypeGCP
+				rt.Spec.Shoot.Provider.Workers[0].Zones = []string{"europe-west3-a", "europe-west3-b"}
+				rt.Spec.Shoot.Provider.GCP = &imv1.GCPProviderConfig{
+					ControlPlaneZone: ptr.To("europe-west3-b"),
+					Internal:         ptr.To("10.251.0.0/24"),
+					CloudNAT: &imv1.GCPCloudNAT{
+						MinPortsPerVM: ptr.To(int32(2048)),
+						NatIPNames:    []string{"nat-ip"},
+					},
+				}
+			},
+		},
+		"Reject GCP control plane zone which is not a worker zone": {
+			modify: func(rt *imv1.Runtime) {
+				rt.Spec.Shoot.Provider.Type = hyperscaler.TypeGCP
+				rt.Spec.Shoot.Provider.Workers[0].Zones = []string{"europe-west3-a"}
+				rt.Spec.Shoot.Provider.GCP = &imv1.GCPProviderConfig{ControlPlaneZone: ptr.To("europe-west3-c")}
+			},
+			expectedError: "spec.shoot.provider.gcp.controlPlaneZone",
+		},
+		"Reject GCP internal CIDR overlapping with nodes CIDR": {
+			modify: func(rt *imv1.Runtime) {
+				rt.Spec.Shoot.Provider.Type = hyperscaler.TypeGCP
+				rt.Spec.Shoot.Provider.Workers[0].Zones = []string{"europe-west3-a"}
+				rt.Spec.Shoot.Provider.GCP = &imv1.GCPProviderConfig{Internal: ptr.To("10.250.1.0/24")}
+			},
+			expectedError: "spec.shoot.provider.gcp.internal",
+		},
+		"Reject GCP settings for AWS runtime": {
+			modify: func(rt *imv1.Runtime) {
+				rt.Spec.Shoot.Provider.GCP = &imv1.GCPProviderConfig{ControlPlaneZone: ptr.To("eu-central-1a")}
+			},
+			expectedError: "spec.shoot.provider.gcp",
+		},
+		"Reject OpenStack settings for AWS runtime": {
+			modify: func(rt *imv1.Runtime) {
+				rt.Spec.Shoot.Provider.OpenStack = &imv1.OpenStackProviderConfig{FloatingPoolName: ptr.To("FloatingIP-external-kyma-02")}
+			},
+			expectedError: "spec.shoot.provider.openstack",
+		},
+		"Accept AWS worker provider config": {
+			modify: func(rt *imv1.Runtime) {
+				rt.Spec.Shoot.Provider.Workers[0].ProviderConfig = &runtime.RawExtension{
+					Raw: []byte(`{"apiVersion":"aws.provider.extensions.gardener.cloud/v1alpha1","kind":"WorkerConfig","volume":{"iops":3000,"throughput":200}}`),
+				}
+			},
+		},
+		"Reject worker provider config of another provider": {
+			modify: func(rt *imv1.Runtime) {
+				rt.Spec.Shoot.Provider.Workers[0].ProviderConfig = &runtime.RawExtension{
+					Raw: []byte(`{"apiVersion":"gcp.provider.extensions.gardener.cloud/v1alpha1","kind":"WorkerConfig"}`),
+				}
+			},
+			expectedError: "spec.shoot.provider.workers[0].providerConfig",
+		},
+		"Reject worker provider config with unknown field": {
+			modify: func(rt *imv1.Runtime) {
+				rt.Spec.Shoot.Provider.Workers[0].ProviderConfig = &runtime.RawExtension{
+					Raw: []byte(`{"apiVersion":"aws.provider.extensions.gardener.cloud/v1alpha1","kind":"WorkerConfig","volumes":{"iops":3000}}`),
+				}
+			},
+			expectedError: "spec.shoot.provider.workers[0].providerConfig",
+		},
+		"Accept Azure worker provider config with data volumes": {
+			modify: func(rt *imv1.Runtime) {
+				rt.Spec.Shoot.Provider.Type = hyperscaler.TypeAzure
+				rt.Spec.Shoot.Provider.Workers[0].Zones = []string{"1", "2", "3"}
+				rt.Spec.Shoot.Provider.Workers[0].ProviderConfig = &runtime.RawExtension{
+					Raw: []byte(`{"apiVersion":"azure.provider.extensions.gardener.cloud/v1alpha1","kind":"WorkerConfig","dataVolumes":[{"name":"data","imageRef":{"urn":"publisher:offer:sku:1.0.0"}}]}`),
+				}
+			},
+		},
+		"Accept GCP worker provider config with GPU": {
+			modify: func(rt *imv1.Runtime) {
+				rt.Spec.Shoot.Provider.Type = hyperscaler.TypeGCP
+				rt.Spec.Shoot.Provider.Workers[0].Zones = []string{"europe-west3-a"}
+				rt.Spec.Shoot.Provider.Workers[0].ProviderConfig = &runtime.RawExtension{
+					Raw: []byte(`{"apiVersion":"gcp.provider.extensions.gardener.cloud/v1alpha1","kind":"WorkerConfig","gpu":{"acceleratorType":"nvidia-tesla-t4","count":1}}`),
+				}
+			},
+		},
+		"Accept worker with rolling update settings": {
+			modify: func(rt *imv1.Runtime) {
+				rt.Spec.Shoot.Provider.Workers[0].MaxSurge = ptr.To(intstr.FromString("50%"))
+				rt.Spec.Shoot.Provider.Workers[0].MaxUnavailable = ptr.To(intstr.FromInt32(0))
+			},
+		},
+		"Reject worker with maximum lower than minimum": {
+			modify: func(rt *imv1.Runtime) {
+				rt.Spec.Shoot.Provider.Workers[0].Minimum = 4
+			},
+			expectedError: "spec.shoot.provider.workers[0]",
+		},
+		"Reject worker with maximum lower than number of zones": {
+			modify: func(rt *imv1.Runtime) {
+				rt.Spec.Shoot.Provider.Workers[0].Maximum = 1
+			},
+			expectedError: "spec.shoot.provider.workers[0]",
+		},
+		"Reject worker with both max surge and max unavailable set to 0": {
+			modify: func(rt *imv1.Runtime) {
+				rt.Spec.Shoot.Provider.Workers[0].MaxSurge = ptr.To(intstr.FromString("0%"))
+				rt.Spec.Shoot.Provider.Workers[0].MaxUnavailable = ptr.To(intstr.FromInt32(0))
+			},
+			expectedError: "spec.shoot.provider.workers[0]",
+		},
+		"Accept orphan deletion policy keeping GardenerCluster": {
+			modify: func(rt *imv1.Runtime) {
+				rt.Spec.Deletion = &imv1.Deletion{Policy: imv1.DeletionPolicyOrphan, KeepGardenerCluster: true}
+			},
+		},
+		"Reject keeping GardenerCluster with delete deletion policy": {
+			modify: func(rt *imv1.Runtime) {
+				rt.Spec.Deletion = &imv1.Deletion{Policy: imv1.DeletionPolicyDelete, KeepGardenerCluster: true}
+			},
+			expectedError: "spec.deletion.keepGardenerCluster",
+		},
+		"Accept hibernation schedule with time zone": {
+			modify: func(rt *imv1.Runtime) {
+				rt.Spec.Shoot.Hibernation = &gardener.Hibernation{
+					Schedules: []gardener.HibernationSchedule{
+						{Start: ptr.To("00 20 * * 1,2,3,4,5"), End: ptr.To("00 07 * * 1,2,3,4,5"), Location: ptr.To("Europe/Berlin")},
+					},
+				}
+			},
+		},
+		"Reject hibernation schedule with invalid time zone": {
+			modify: func(rt *imv1.Runtime) {
+				rt.Spec.Shoot.Hibernation = &gardener.Hibernation{
+					Schedules: []gardener.HibernationSchedule{
+						{Start: ptr.To("00 20 * * 1,2,3,4,5"), Location: ptr.To("Mars/Olympus")},
+					},
+				}
+			},
+			expectedError: "spec.shoot.hibernation.schedules[0].location",
+		},
+		"Reject hibernation schedule without start and end": {
+			modify: func(rt *imv1.Runtime) {
+				rt.Spec.Shoot.Hibernation = &gardener.Hibernation{
+					Schedules: []gardener.HibernationSchedule{{}},
+				}
+			},
+			expectedError: "spec.shoot.hibernation.schedules[0]",
+		},
+		"Accept maintenance time window": {
+			modify: func(rt *imv1.Runtime) {
+				rt.Spec.Shoot.Maintenance = &imv1.Maintenance{
+					TimeWindow: &gardener.MaintenanceTimeWindow{Begin: "220000+0000", End: "230000+0000"},
+				}
+			},
+		},
+		"Accept project configured for runtimes": {
+			modify: func(rt *imv1.Runtime) {
+				rt.Spec.Shoot.ProjectName = "kyma-additional"
+			},
+		},
+		"Reject project not configured for runtimes": {
+			modify: func(rt *imv1.Runtime) {
+				rt.Spec.Shoot.ProjectName = "unknown"
+			},
+			expectedError: "spec.shoot.projectName",
+		},
+		"Reject invalid maintenance time window": {
+			modify: func(rt *imv1.Runtime) {
+				rt.Spec.Shoot.Maintenance = &imv1.Maintenance{
+					TimeWindow: &gardener.MaintenanceTimeWindow{Begin: "22:00", End: "23:00"},
+				}
+			},
+			expectedError: "spec.shoot.maintenance.timeWindow",
+		},
+	} {
+		t.Run(tname, func(t *testing.T) {
+			// given
+			validator := NewRuntimeValidator(fixConfig())
+			runtime := fixRuntime()
+			testCase.modify(&runtime)
+
+			// when
+			_, err := validator.ValidateCreate(context.Background(), &runtime)
+
+			// then
+			if testCase.expectedError == "" {
+				require.NoError(t, err)
+				return
+			}
+
+			require.Error(t, err)
+			assert.True(t, apierrors.IsInvalid(err))
+			assert.Contains(t, err.Error(), testCase.expectedError)
+		})
+	}
+
+	t.Run("Skip validation of runtime being deleted", func(t *testing.T) {
+		// given
+		validator := NewRuntimeValidator(fixConfig())
+		oldRuntime := fixRuntime()
+		newRuntime := fixRuntime()
+		newRuntime.Labels = nil
+		now := metav1.Now()
+		newRuntime.DeletionTimestamp = &now
+
+		// when
+		_, err := validator.ValidateUpdate(context.Background(), &oldRuntime, &newRuntime)
+
+		// then
+		require.NoError(t, err)
+	})
+
+	t.Run("Reject deletion of protected runtime", func(t *testing.T) {
+		// given
+		validator := NewRuntimeValidator(fixConfig())
+		runtime := fixRuntime()
+		runtime.Annotations = map[string]string{imv1.AnnotationDeletionProtection: "true"}
+
+		// when
+		_, err := validator.ValidateDelete(context.Background(), &runtime)
+
+		// then
+		require.Error(t, err)
+		assert.True(t, apierrors.IsForbidden(err))
+	})
+
+	t.Run("Accept deletion of runtime without protection", func(t *testing.T) {
+		// given
+		validator := NewRuntimeValidator(fixConfig())
+		runtime := fixRuntime()
+
+		// when
+		_, err := validator.ValidateDelete(context.Background(), &runtime)
+
+		// then
+		require.NoError(t, err)
+	})
+}
+
+func fixRuntime() imv1.Runtime {
+	return imv1.Runtime{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "runtime",
+			Namespace: "kcp-system",
+			Labels: map[string]string{
+				imv1.LabelKymaInstanceID:      "instance-id",
+				imv1.LabelKymaRuntimeID:       "runtime-id",
+				imv1.LabelKymaRegion:          "eu-central-1",
+				imv1.LabelKymaName:            "kyma-name",
+				imv1.LabelKymaBrokerPlanID:    "plan-id",
+				imv1.LabelKymaBrokerPlanName:  "aws",
+				imv1.LabelKymaGlobalAccountID: "global-account-id",
+				imv1.LabelKymaSubaccountID:    "subaccount-id",
+			},
+		},
+		Spec: imv1.RuntimeSpec{
+			Shoot: imv1.RuntimeShoot{
+				Name:   "shoot",
+				Region: "eu-central-1",
+				Provider: imv1.Provider{
+					Type: hyperscaler.TypeAWS,
+					Workers: []gardener.Worker{
+						{
+							Name:    "worker",
+							Minimum: 1,
+							Maximum: 3,
+							Zones:   []string{"eu-central-1a", "eu-central-1b"},
+						},
+					},
+				},
+				Networking: imv1.Networking{
+					Nodes:    "10.250.0.0/22",
+					Pods:     "10.96.0.0/13",
+					Services: "10.104.0.0/13",
+				},
+			},
+		},
+	}
+}
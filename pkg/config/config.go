@@ -3,6 +3,9 @@ package config
 import (
 	"encoding/json"
 	"io"
+
+	gardener "github.com/gardener/gardener/pkg/apis/core/v1beta1"
+	"k8s.io/apimachinery/pkg/util/intstr"
 )
 
 type Config struct {
@@ -15,13 +18,49 @@ type ClusterConfig struct {
 }
 
 type ProviderConfig struct {
-	AWS AWSConfig `json:"aws"`
+	AWS       AWSConfig       `json:"aws"`
+	OpenStack OpenStackConfig `json:"openstack"`
+	// ZoneBits maps the provider type to the zone layout of the nodes CIDR written into the new Runtimes,
+	// the existing Runtimes keep their layout. The built-in layout of the provider is used if not set
+	ZoneBits map[string]int `json:"zoneBits,omitempty"`
 }
 
 type AWSConfig struct {
 	EnableIMDSv2 bool `json:"enableIMDSv2"`
 }
 
+type OpenStackConfig struct {
+	// Default contains the settings of the regions not listed in Regions, the built-in defaults are used for its empty fields
+	Default OpenStackRegionConfig `json:"default"`
+	// Regions maps the region to its settings, the empty fields are taken from Default
+	Regions map[string]OpenStackRegionConfig `json:"regions,omitempty"`
+}
+
+type OpenStackRegionConfig struct {
+	FloatingPoolName     string `json:"floatingPoolName,omitempty"`
+	LoadBalancerProvider string `json:"loadBalancerProvider,omitempty"`
+	ExposureClassName    string `json:"exposureClassName,omitempty"`
+}
+
+// ForRegion returns the settings of the region, completed with the default ones
+func (c OpenStackConfig) ForRegion(region string) OpenStackRegionConfig {
+	regionConfig := c.Regions[region]
+
+	return OpenStackRegionConfig{
+		FloatingPoolName:     valueOrDefault(regionConfig.FloatingPoolName, c.Default.FloatingPoolName),
+		LoadBalancerProvider: valueOrDefault(regionConfig.LoadBalancerProvider, c.Default.LoadBalancerProvider),
+		ExposureClassName:    valueOrDefault(regionConfig.ExposureClassName, c.Default.ExposureClassName),
+	}
+}
+
+func valueOrDefault(value, defaultValue string) string {
+	if value == "" {
+		return defaultValue
+	}
+
+	return value
+}
+
 type DNSConfig struct {
 	SecretName   string `json:"secretName" validate:"required"`
 	DomainPrefix string `json:"domainPrefix" validate:"required"`
@@ -35,6 +74,16 @@ type KubernetesConfig struct {
 	DefaultOperatorOidc                 OidcProvider `json:"defaultOperatorOidc" validate:"required"`
 }
 
+type MaintenanceWindowConfig struct {
+	// WindowsByRegion maps the region to the default maintenance time window of the shoots in this region
+	WindowsByRegion map[string]MaintenanceTimeWindow `json:"windowsByRegion" validate:"dive"`
+}
+
+type MaintenanceTimeWindow struct {
+	Begin string `json:"begin" validate:"required"`
+	End   string `json:"end" validate:"required"`
+}
+
 type OidcProvider struct {
 	ClientID       string   `json:"clientID" validate:"required"`
 	GroupsClaim    string   `json:"groupsClaim" validate:"required"`
@@ -51,20 +100,49 @@ type AuditLogConfig struct {
 
 type GardenerConfig struct {
 	ProjectName string `json:"projectName" validate:"required"`
+	// AdditionalProjectNames lists the other Gardener projects which can be selected in the Runtime
+	AdditionalProjectNames []string `json:"additionalProjectNames,omitempty"`
+}
+
+// ProjectNames returns the names of all Gardener projects the shoots can be created in
+func (c GardenerConfig) ProjectNames() []string {
+	return append([]string{c.ProjectName}, c.AdditionalProjectNames...)
+}
+
+type CloudProfileConfig struct {
+	// NamesByProvider maps the provider type to the name of the cloud profile, overriding the default one
+	NamesByProvider map[string]string `json:"namesByProvider,omitempty"`
+	// NamesByRegion maps the provider type and the region to the name of the cloud profile, it takes precedence over NamesByProvider
+	NamesByRegion map[string]map[string]string `json:"namesByRegion,omitempty"`
 }
 
 type MachineImageConfig struct {
-	DefaultName    string `json:"defaultName" validate:"required"`
+	DefaultName string `json:"defaultName" validate:"required"`
+	// DefaultVersion is written into the Runtime by the defaulting webhook, and used when the cloud profile offers no supported version
 	DefaultVersion string `json:"defaultVersion" validate:"required"`
+	// PinnedVersion, if set, is used instead of the default version and the latest supported version from the cloud profile
+	PinnedVersion string `json:"pinnedVersion,omitempty"`
 }
 
 type ConverterConfig struct {
-	Kubernetes   KubernetesConfig   `json:"kubernetes" validate:"required"`
-	DNS          DNSConfig          `json:"dns" validate:"required"`
-	Provider     ProviderConfig     `json:"provider"`
-	MachineImage MachineImageConfig `json:"machineImage" validate:"required"`
-	Gardener     GardenerConfig     `json:"gardener" validate:"required"`
-	AuditLog     AuditLogConfig     `json:"auditLogging" validate:"required"`
+	Kubernetes        KubernetesConfig        `json:"kubernetes" validate:"required"`
+	DNS               DNSConfig               `json:"dns" validate:"required"`
+	Provider          ProviderConfig          `json:"provider"`
+	MachineImage      MachineImageConfig      `json:"machineImage" validate:"required"`
+	Gardener          GardenerConfig          `json:"gardener" validate:"required"`
+	AuditLog          AuditLogConfig          `json:"auditLogging" validate:"required"`
+	MaintenanceWindow MaintenanceWindowConfig `json:"maintenanceWindow"`
+	CloudProfile      CloudProfileConfig      `json:"cloudProfile"`
+	Autoscaler        AutoscalerConfig        `json:"autoscaler"`
+}
+
+type AutoscalerConfig struct {
+	// ClusterAutoscaler contains the default settings of the cluster autoscaler, the ones set in the Runtime take precedence
+	ClusterAutoscaler gardener.ClusterAutoscaler `json:"clusterAutoscaler"`
+	// WorkerMaxSurge is the default number of the machines created above the desired number during the rolling update of a worker pool
+	WorkerMaxSurge *intstr.IntOrString `json:"workerMaxSurge,omitempty"`
+	// WorkerMaxUnavailable is the default number of the machines which can be unavailable during the rolling update of a worker pool
+	WorkerMaxUnavailable *intstr.IntOrString `json:"workerMaxUnavailable,omitempty"`
 }
 
 type ReaderGetter = func() (io.Reader, error)
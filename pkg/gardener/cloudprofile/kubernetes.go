@@ -0,0 +1,134 @@
+package cloudprofile
+
+import (
+	"fmt"
+	"time"
+
+	gardener "github.com/gardener/gardener/pkg/apis/core/v1beta1"
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/util/version"
+)
+
+var (
+	ErrKubernetesDowngrade        = errors.New("kubernetes version downgrade is not supported")
+	ErrKubernetesMinorVersionSkip = errors.New("kubernetes version upgrade can skip no minor version")
+	ErrKubernetesVersionNotFound  = errors.New("kubernetes version not found in cloud profile")
+	ErrKubernetesVersionExpired   = errors.New("kubernetes version expired")
+)
+
+// IsKubernetesVersionChanged returns true when the requested version differs from the current version of the shoot.
+// The requested version may omit the patch, then only the minor versions are compared, as Gardener picks the patch.
+// A newer patch of the requested minor version is not a change, Gardener updates the patch versions during the maintenance
+func IsKubernetesVersionChanged(currentVersion, requestedVersion string) (bool, error) {
+	current, requested, err := parseVersions(currentVersion, requestedVersion)
+	if err != nil {
+		return false, err
+	}
+
+	if isPatchUpdate(current, requested) {
+		return false, nil
+	}
+
+	return compareVersions(current, requested) != 0, nil
+}
+
+// IsKubernetesPatchUpdated returns true when the current version of the shoot is a newer patch of the requested minor version
+func IsKubernetesPatchUpdated(currentVersion, requestedVersion string) bool {
+	current, requested, err := parseVersions(currentVersion, requestedVersion)
+	if err != nil {
+		return false
+	}
+
+	return isPatchUpdate(current, requested)
+}
+
+// ValidateKubernetesUpgrade checks whether the shoot can be upgraded from the current to the requested version:
+// no downgrade, at most one minor version step, and the requested version offered and not expired in the cloud profile
+func ValidateKubernetesUpgrade(profile gardener.CloudProfile, currentVersion, requestedVersion string, now time.Time) error {
+	current, requested, err := parseVersions(currentVersion, requestedVersion)
+	if err != nil {
+		return err
+	}
+
+	if compareVersions(current, requested) > 0 {
+		return errors.Wrapf(ErrKubernetesDowngrade, "from %s to %s", currentVersion, requestedVersion)
+	}
+
+	if requested.Major() != current.Major() || requested.Minor() > current.Minor()+1 {
+		return errors.Wrapf(ErrKubernetesMinorVersionSkip, "from %s to %s", currentVersion, requestedVersion)
+	}
+
+	return validateKubernetesVersionOffered(profile, requested, now)
+}
+
+func validateKubernetesVersionOffered(profile gardener.CloudProfile, requested *version.Version, now time.Time) error {
+	found := false
+
+	for _, offered := range profile.Spec.Kubernetes.Versions {
+		offeredVersion, err := version.ParseGeneric(offered.Version)
+		if err != nil || !matchesVersion(offeredVersion, requested) {
+			continue
+		}
+
+		found = true
+		if !isExpired(offered, now) {
+			return nil
+		}
+	}
+
+	if found {
+		return errors.Wrapf(ErrKubernetesVersionExpired, "version %s in cloud profile %s", requested, profile.Name)
+	}
+
+	return errors.Wrapf(ErrKubernetesVersionNotFound, "version %s in cloud profile %s", requested, profile.Name)
+}
+
+func parseVersions(currentVersion, requestedVersion string) (*version.Version, *version.Version, error) {
+	current, err := version.ParseGeneric(currentVersion)
+	if err != nil {
+		return nil, nil, fmt.Errorf("invalid current kubernetes version %s: %w", currentVersion, err)
+	}
+
+	requested, err := version.ParseGeneric(requestedVersion)
+	if err != nil {
+		return nil, nil, fmt.Errorf("invalid requested kubernetes version %s: %w", requestedVersion, err)
+	}
+
+	return current, requested, nil
+}
+
+// compareVersions compares the patch versions only if the requested version contains the patch
+func compareVersions(current, requested *version.Version) int {
+	if !hasPatch(requested) {
+		current = version.MajorMinor(current.Major(), current.Minor())
+	}
+
+	if current.LessThan(requested) {
+		return -1
+	}
+
+	if requested.LessThan(current) {
+		return 1
+	}
+
+	return 0
+}
+
+func matchesVersion(offered, requested *version.Version) bool {
+	if hasPatch(requested) {
+		return offered.String() == requested.String()
+	}
+
+	return offered.Major() == requested.Major() && offered.Minor() == requested.Minor()
+}
+
+func isPatchUpdate(current, requested *version.Version) bool {
+	return hasPatch(current) && hasPatch(requested) &&
+		current.Major() == requested.Major() &&
+		current.Minor() == requested.Minor() &&
+		current.Patch() > requested.Patch()
+}
+
+func hasPatch(v *version.Version) bool {
+	return len(v.Components()) > 2
+}
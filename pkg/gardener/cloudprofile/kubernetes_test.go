@@ -0,0 +1,121 @@
+package cloudprofile
+
+import (
+	"testing"
+	"time"
+
+	gardener "github.com/gardener/gardener/pkg/apis/core/v1beta1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestValidateKubernetesUpgrade(t *testing.T) {
+	now := time.Date(2024, 10, 1, 0, 0, 0, 0, time.UTC)
+	profile := fixCloudProfile(now)
+
+	for tname, tc := range map[string]struct {
+		current     string
+		requested   string
+		expectedErr error
+	}{
+		"Should allow patch upgrade": {
+			current:   "1.29.5",
+			requested: "1.29.8",
+		},
+		"Should allow minor upgrade": {
+			current:   "1.29.8",
+			requested: "1.30.4",
+		},
+		"Should allow minor upgrade without patch version": {
+			current:   "1.29.8",
+			requested: "1.30",
+		},
+		"Should reject downgrade": {
+			current:     "1.30.4",
+			requested:   "1.29.8",
+			expectedErr: ErrKubernetesDowngrade,
+		},
+		"Should reject patch downgrade": {
+			current:     "1.29.8",
+			requested:   "1.29.5",
+			expectedErr: ErrKubernetesDowngrade,
+		},
+		"Should reject skipping minor version": {
+			current:     "1.29.8",
+			requested:   "1.31.1",
+			expectedErr: ErrKubernetesMinorVersionSkip,
+		},
+		"Should reject version not offered by cloud profile": {
+			current:     "1.29.8",
+			requested:   "1.29.9",
+			expectedErr: ErrKubernetesVersionNotFound,
+		},
+		"Should reject expired version": {
+			current:     "1.28.10",
+			requested:   "1.29.5",
+			expectedErr: ErrKubernetesVersionExpired,
+		},
+	} {
+		t.Run(tname, func(t *testing.T) {
+			// when
+			err := ValidateKubernetesUpgrade(profile, tc.current, tc.requested, now)
+
+			// then
+			if tc.expectedErr == nil {
+				require.NoError(t, err)
+			} else {
+				require.ErrorIs(t, err, tc.expectedErr)
+			}
+		})
+	}
+}
+
+func TestIsKubernetesVersionChanged(t *testing.T) {
+	for tname, tc := range map[string]struct {
+		current   string
+		requested string
+		expected  bool
+	}{
+		"Should detect patch change":                       {current: "1.29.5", requested: "1.29.8", expected: true},
+		"Should detect minor change":                       {current: "1.29.5", requested: "1.30", expected: true},
+		"Should ignore patch when requested without patch": {current: "1.29.5", requested: "1.29", expected: false},
+		"Should detect no change":                          {current: "1.29.5", requested: "1.29.5", expected: false},
+		"Should ignore patch updated during maintenance":   {current: "1.29.8", requested: "1.29.5", expected: false},
+		"Should detect minor downgrade":                    {current: "1.30.1", requested: "1.29.5", expected: true},
+	} {
+		t.Run(tname, func(t *testing.T) {
+			// when
+			changed, err := IsKubernetesVersionChanged(tc.current, tc.requested)
+
+			// then
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, changed)
+		})
+	}
+
+	t.Run("Should return error for invalid version", func(t *testing.T) {
+		// when
+		_, err := IsKubernetesVersionChanged("1.29.5", "latest")
+
+		// then
+		require.Error(t, err)
+	})
+}
+
+func fixCloudProfile(now time.Time) gardener.CloudProfile {
+	return gardener.CloudProfile{
+		ObjectMeta: metav1.ObjectMeta{Name: "aws"},
+		Spec: gardener.CloudProfileSpec{
+			Kubernetes: gardener.KubernetesSettings{
+				Versions: []gardener.ExpirableVersion{
+					{Version: "1.31.1"},
+					{Version: "1.30.4"},
+					{Version: "1.29.8"},
+					{Version: "1.29.5", ExpirationDate: &metav1.Time{Time: now.Add(-time.Hour)}},
+					{Version: "1.28.10"},
+				},
+			},
+		},
+	}
+}
@@ -0,0 +1,105 @@
+package cloudprofile
+
+import (
+	"time"
+
+	gardener "github.com/gardener/gardener/pkg/apis/core/v1beta1"
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/util/version"
+)
+
+var (
+	ErrMachineImageNotFound        = errors.New("machine image not found in cloud profile")
+	ErrMachineImageVersionNotFound = errors.New("machine image version not found in cloud profile")
+	ErrMachineImageVersionExpired  = errors.New("machine image version expired")
+)
+
+// LatestMachineImageVersion returns the highest supported version of the machine image offered by the cloud profile,
+// preview and expired versions are skipped
+func LatestMachineImageVersion(profile gardener.CloudProfile, imageName string, now time.Time) (string, error) {
+	image := findMachineImage(profile, imageName)
+	if image == nil {
+		return "", errors.Wrapf(ErrMachineImageNotFound, "image %s in cloud profile %s", imageName, profile.Name)
+	}
+
+	var latest *version.Version
+	latestVersion := ""
+
+	for _, offered := range image.Versions {
+		if isPreview(offered.ExpirableVersion) || isExpired(offered.ExpirableVersion, now) {
+			continue
+		}
+
+		offeredVersion, err := version.ParseGeneric(offered.Version)
+		if err != nil {
+			continue
+		}
+
+		if latest == nil || latest.LessThan(offeredVersion) {
+			latest = offeredVersion
+			latestVersion = offered.Version
+		}
+	}
+
+	if latest == nil {
+		return "", errors.Wrapf(ErrMachineImageVersionNotFound, "no supported version of image %s in cloud profile %s", imageName, profile.Name)
+	}
+
+	return latestVersion, nil
+}
+
+// ValidateMachineImageVersion checks whether the given version of the machine image is offered by the cloud profile and not expired
+func ValidateMachineImageVersion(profile gardener.CloudProfile, imageName, imageVersion string, now time.Time) error {
+	image := findMachineImage(profile, imageName)
+	if image == nil {
+		return errors.Wrapf(ErrMachineImageNotFound, "image %s in cloud profile %s", imageName, profile.Name)
+	}
+
+	for _, offered := range image.Versions {
+		if offered.Version != imageVersion {
+			continue
+		}
+
+		if isExpired(offered.ExpirableVersion, now) {
+			return errors.Wrapf(ErrMachineImageVersionExpired, "image %s version %s in cloud profile %s", imageName, imageVersion, profile.Name)
+		}
+
+		return nil
+	}
+
+	return errors.Wrapf(ErrMachineImageVersionNotFound, "image %s version %s in cloud profile %s", imageName, imageVersion, profile.Name)
+}
+
+// IsMachineImageVersionNewer returns true when the current version of the machine image is higher than the requested one,
+// e.g. after the update done by Gardener during the maintenance
+func IsMachineImageVersionNewer(currentVersion, requestedVersion string) bool {
+	current, err := version.ParseGeneric(currentVersion)
+	if err != nil {
+		return false
+	}
+
+	requested, err := version.ParseGeneric(requestedVersion)
+	if err != nil {
+		return false
+	}
+
+	return requested.LessThan(current)
+}
+
+func findMachineImage(profile gardener.CloudProfile, imageName string) *gardener.MachineImage {
+	for i := range profile.Spec.MachineImages {
+		if profile.Spec.MachineImages[i].Name == imageName {
+			return &profile.Spec.MachineImages[i]
+		}
+	}
+
+	return nil
+}
+
+func isPreview(v gardener.ExpirableVersion) bool {
+	return v.Classification != nil && *v.Classification == gardener.ClassificationPreview
+}
+
+func isExpired(v gardener.ExpirableVersion, now time.Time) bool {
+	return v.ExpirationDate != nil && !v.ExpirationDate.Time.After(now)
+}
@@ -0,0 +1,97 @@
+package cloudprofile
+
+import (
+	"testing"
+	"time"
+
+	gardener "github.com/gardener/gardener/pkg/apis/core/v1beta1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+)
+
+func TestLatestMachineImageVersion(t *testing.T) {
+	now := time.Date(2024, 10, 1, 0, 0, 0, 0, time.UTC)
+	profile := fixMachineImageCloudProfile(now)
+
+	t.Run("Should return latest supported version", func(t *testing.T) {
+		// when
+		latestVersion, err := LatestMachineImageVersion(profile, "gardenlinux", now)
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, "1592.1.0", latestVersion)
+	})
+
+	t.Run("Should return error for unknown machine image", func(t *testing.T) {
+		// when
+		_, err := LatestMachineImageVersion(profile, "ubuntu", now)
+
+		// then
+		require.ErrorIs(t, err, ErrMachineImageNotFound)
+	})
+
+	t.Run("Should return error when only preview and expired versions are offered", func(t *testing.T) {
+		// when
+		_, err := LatestMachineImageVersion(profile, "suse-chost", now)
+
+		// then
+		require.ErrorIs(t, err, ErrMachineImageVersionNotFound)
+	})
+}
+
+func TestValidateMachineImageVersion(t *testing.T) {
+	now := time.Date(2024, 10, 1, 0, 0, 0, 0, time.UTC)
+	profile := fixMachineImageCloudProfile(now)
+
+	for tname, tc := range map[string]struct {
+		imageName    string
+		imageVersion string
+		expectedErr  error
+	}{
+		"Should accept supported version":     {imageName: "gardenlinux", imageVersion: "1443.10.0"},
+		"Should accept preview version":       {imageName: "gardenlinux", imageVersion: "1592.2.0"},
+		"Should reject expired version":       {imageName: "gardenlinux", imageVersion: "1312.3.0", expectedErr: ErrMachineImageVersionExpired},
+		"Should reject unknown version":       {imageName: "gardenlinux", imageVersion: "1000.0.0", expectedErr: ErrMachineImageVersionNotFound},
+		"Should reject unknown machine image": {imageName: "ubuntu", imageVersion: "22.04", expectedErr: ErrMachineImageNotFound},
+	} {
+		t.Run(tname, func(t *testing.T) {
+			// when
+			err := ValidateMachineImageVersion(profile, tc.imageName, tc.imageVersion, now)
+
+			// then
+			if tc.expectedErr == nil {
+				require.NoError(t, err)
+			} else {
+				require.ErrorIs(t, err, tc.expectedErr)
+			}
+		})
+	}
+}
+
+func fixMachineImageCloudProfile(now time.Time) gardener.CloudProfile {
+	return gardener.CloudProfile{
+		ObjectMeta: metav1.ObjectMeta{Name: "aws"},
+		Spec: gardener.CloudProfileSpec{
+			MachineImages: []gardener.MachineImage{
+				{
+					Name: "gardenlinux",
+					Versions: []gardener.MachineImageVersion{
+						{ExpirableVersion: gardener.ExpirableVersion{Version: "1592.2.0", Classification: ptr.To(gardener.ClassificationPreview)}},
+						{ExpirableVersion: gardener.ExpirableVersion{Version: "1592.1.0", Classification: ptr.To(gardener.ClassificationSupported)}},
+						{ExpirableVersion: gardener.ExpirableVersion{Version: "1443.10.0", Classification: ptr.To(gardener.ClassificationSupported)}},
+						{ExpirableVersion: gardener.ExpirableVersion{Version: "1312.3.0", ExpirationDate: &metav1.Time{Time: now.Add(-time.Hour)}}},
+					},
+				},
+				{
+					Name: "suse-chost",
+					Versions: []gardener.MachineImageVersion{
+						{ExpirableVersion: gardener.ExpirableVersion{Version: "15.6.20240901", Classification: ptr.To(gardener.ClassificationPreview)}},
+						{ExpirableVersion: gardener.ExpirableVersion{Version: "15.5.20240101", ExpirationDate: &metav1.Time{Time: now.Add(-time.Hour)}}},
+					},
+				},
+			},
+		},
+	}
+}
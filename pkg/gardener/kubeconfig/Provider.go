@@ -12,7 +12,7 @@ import (
 
 type Provider struct {
 	shootNamespace       string
-	shootClient          ShootClient
+	shootClients         ShootClientFactory
 	dynamicKubeconfigAPI DynamicKubeconfigAPI
 	expirationInSeconds  int64
 }
@@ -21,25 +21,33 @@ type ShootClient interface {
 	Get(ctx context.Context, name string, opts v1.GetOptions) (*v1beta1.Shoot, error)
 }
 
+// ShootClientFactory returns the client of the shoots in the Gardener project namespace
+type ShootClientFactory func(namespace string) ShootClient
+
 type DynamicKubeconfigAPI interface {
 	Create(ctx context.Context, obj gardenerClient.Object, subResource gardenerClient.Object, opts ...gardenerClient.SubResourceCreateOption) error
 }
 
 func NewKubeconfigProvider(
-	shootClient ShootClient,
+	shootClients ShootClientFactory,
 	dynamicKubeconfigAPI DynamicKubeconfigAPI,
 	shootNamespace string,
 	expirationInSeconds int64) Provider {
 	return Provider{
-		shootClient:          shootClient,
+		shootClients:         shootClients,
 		dynamicKubeconfigAPI: dynamicKubeconfigAPI,
 		shootNamespace:       shootNamespace,
 		expirationInSeconds:  expirationInSeconds,
 	}
 }
 
-func (kp Provider) Fetch(ctx context.Context, shootName string) (string, error) {
-	shoot, err := kp.shootClient.Get(ctx, shootName, v1.GetOptions{})
+// Fetch returns the admin kubeconfig of the shoot, the shoot is read from the default project namespace if shootNamespace is empty
+func (kp Provider) Fetch(ctx context.Context, shootName, shootNamespace string) (string, error) {
+	if shootNamespace == "" {
+		shootNamespace = kp.shootNamespace
+	}
+
+	shoot, err := kp.shootClients(shootNamespace).Get(ctx, shootName, v1.GetOptions{})
 	if err != nil {
 		return "", errors.Wrap(err, "failed to get shoot")
 	}
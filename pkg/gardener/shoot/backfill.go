@@ -0,0 +1,98 @@
+package shoot
+
+import (
+	gardener "github.com/gardener/gardener/pkg/apis/core/v1beta1"
+	imv1 "github.com/kyma-project/infrastructure-manager/api/v1"
+	extender2 "github.com/kyma-project/infrastructure-manager/pkg/gardener/shoot/extender"
+)
+
+// provisionerLicenceTypeAnnotation was set by the Provisioner on the shoots it created
+const provisionerLicenceTypeAnnotation = "kcp.provisioner.kyma-project.io/licence-type"
+
+// BackfillRuntime copies the settings of the existing shoot into the fields of the Runtime which are not set,
+// so that the conversion of the Runtime reproduces the shoot. The values already set in the Runtime are kept.
+// The immutable fields (e.g. the region, the provider type or the networking) cannot be set after the Runtime is created,
+// they are not back-filled
+func BackfillRuntime(runtime *imv1.Runtime, shoot gardener.Shoot) {
+	runtimeShoot := &runtime.Spec.Shoot
+
+	if runtimeShoot.Purpose == "" && shoot.Spec.Purpose != nil {
+		runtimeShoot.Purpose = *shoot.Spec.Purpose
+	}
+	if runtimeShoot.SecretBindingName == "" && shoot.Spec.SecretBindingName != nil {
+		runtimeShoot.SecretBindingName = *shoot.Spec.SecretBindingName
+	}
+	if runtimeShoot.LicenceType == nil {
+		runtimeShoot.LicenceType = getLicenceType(shoot)
+	}
+	if runtimeShoot.ControlPlane == nil && shoot.Spec.ControlPlane != nil && shoot.Spec.ControlPlane.HighAvailability != nil {
+		runtimeShoot.ControlPlane = &gardener.ControlPlane{
+			HighAvailability: &gardener.HighAvailability{
+				FailureTolerance: gardener.FailureTolerance{
+					Type: shoot.Spec.ControlPlane.HighAvailability.FailureTolerance.Type,
+				},
+			},
+		}
+	}
+
+	backfillKubernetes(&runtimeShoot.Kubernetes, shoot.Spec.Kubernetes)
+	backfillWorkers(&runtimeShoot.Provider, shoot.Spec.Provider)
+
+	if !runtime.Spec.Security.Networking.Filter.Egress.Enabled {
+		runtime.Spec.Security.Networking.Filter.Egress.Enabled = isNetworkFilterEnabled(shoot)
+	}
+}
+
+func getLicenceType(shoot gardener.Shoot) *string {
+	for _, annotation := range []string{extender2.ShootLicenceTypeAnnotation, provisionerLicenceTypeAnnotation} {
+		if licenceType := shoot.Annotations[annotation]; licenceType != "" {
+			return &licenceType
+		}
+	}
+	return nil
+}
+
+func backfillKubernetes(kubernetes *imv1.Kubernetes, shootKubernetes gardener.Kubernetes) {
+	if kubernetes.Version == nil && shootKubernetes.Version != "" {
+		version := shootKubernetes.Version
+		kubernetes.Version = &version
+	}
+
+	if shootKubernetes.KubeAPIServer == nil || shootKubernetes.KubeAPIServer.OIDCConfig == nil {
+		return
+	}
+
+	if kubernetes.KubeAPIServer.OidcConfig.ClientID == nil {
+		oidcConfig := *shootKubernetes.KubeAPIServer.OIDCConfig.DeepCopy()
+		// the client authentication is not managed by the converter
+		oidcConfig.ClientAuthentication = nil
+		kubernetes.KubeAPIServer.OidcConfig = oidcConfig
+	}
+	if kubernetes.KubeAPIServer.AdditionalOidcConfig == nil {
+		kubernetes.KubeAPIServer.AdditionalOidcConfig = &[]gardener.OIDCConfig{kubernetes.KubeAPIServer.OidcConfig}
+	}
+}
+
+func backfillWorkers(provider *imv1.Provider, shootProvider gardener.Provider) {
+	if len(provider.Workers) > 0 {
+		return
+	}
+
+	for _, worker := range shootProvider.Workers {
+		worker := *worker.DeepCopy()
+		// the fields are not set by the converter, they are defaulted by Gardener
+		worker.Machine.Architecture = nil
+		worker.SystemComponents = nil
+		worker.CRI = nil
+		provider.Workers = append(provider.Workers, worker)
+	}
+}
+
+func isNetworkFilterEnabled(shoot gardener.Shoot) bool {
+	for _, extension := range shoot.Spec.Extensions {
+		if extension.Type == extender2.NetworkFilterType {
+			return extension.Disabled == nil || !*extension.Disabled
+		}
+	}
+	return false
+}
@@ -0,0 +1,75 @@
+package shoot
+
+import (
+	"testing"
+
+	gardener "github.com/gardener/gardener/pkg/apis/core/v1beta1"
+	imv1 "github.com/kyma-project/infrastructure-manager/api/v1"
+	"github.com/kyma-project/infrastructure-manager/pkg/gardener/shoot/drift"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/utils/ptr"
+)
+
+func TestBackfillRuntime(t *testing.T) {
+	t.Run("Reproduce existing shoot from back-filled Runtime", func(t *testing.T) {
+		// given
+		converter := NewConverter(fixConverterConfig())
+		existingShoot, err := converter.ToShoot(fixRuntime())
+		require.NoError(t, err)
+		existingShoot.Annotations[provisionerLicenceTypeAnnotation] = "partner"
+
+		runtime := fixAdoptingRuntime()
+
+		// when
+		BackfillRuntime(&runtime, existingShoot)
+
+		// then
+		assert.Equal(t, gardener.ShootPurpose("production"), runtime.Spec.Shoot.Purpose)
+		assert.Equal(t, "my-secret", runtime.Spec.Shoot.SecretBindingName)
+		assert.Equal(t, ptr.To("1.28"), runtime.Spec.Shoot.Kubernetes.Version)
+		assert.Equal(t, ptr.To("partner"), runtime.Spec.Shoot.LicenceType)
+		require.Len(t, runtime.Spec.Shoot.Provider.Workers, 1)
+		assert.Nil(t, runtime.Spec.Shoot.Provider.Workers[0].CRI)
+
+		convertedShoot, err := converter.ToShoot(runtime)
+		require.NoError(t, err)
+		differences, err := drift.Compare(convertedShoot, existingShoot)
+		require.NoError(t, err)
+		assert.Empty(t, differences)
+	})
+
+	t.Run("Keep values set in Runtime", func(t *testing.T) {
+		// given
+		existingShoot, err := NewConverter(fixConverterConfig()).ToShoot(fixRuntime())
+		require.NoError(t, err)
+
+		runtime := fixAdoptingRuntime()
+		runtime.Spec.Shoot.Kubernetes.Version = ptr.To("1.30")
+		runtime.Spec.Shoot.Provider.Workers = []gardener.Worker{{Name: "other"}}
+
+		// when
+		BackfillRuntime(&runtime, existingShoot)
+
+		// then
+		assert.Equal(t, ptr.To("1.30"), runtime.Spec.Shoot.Kubernetes.Version)
+		assert.Equal(t, []gardener.Worker{{Name: "other"}}, runtime.Spec.Shoot.Provider.Workers)
+	})
+}
+
+// fixAdoptingRuntime returns the Runtime with only the immutable fields of fixRuntime set
+func fixAdoptingRuntime() imv1.Runtime {
+	runtime := fixRuntime()
+
+	return imv1.Runtime{
+		ObjectMeta: runtime.ObjectMeta,
+		Spec: imv1.RuntimeSpec{
+			Shoot: imv1.RuntimeShoot{
+				Name:       runtime.Spec.Shoot.Name,
+				Region:     runtime.Spec.Shoot.Region,
+				Provider:   imv1.Provider{Type: runtime.Spec.Shoot.Provider.Type},
+				Networking: runtime.Spec.Shoot.Networking,
+			},
+		},
+	}
+}
@@ -18,19 +18,30 @@ type Converter struct {
 }
 
 func NewConverter(config config.ConverterConfig) Converter {
+	return newConverter(config, nil)
+}
+
+// NewConverterWithCloudProfile creates the converter resolving the machine image versions from the given cloud profile
+func NewConverterWithCloudProfile(config config.ConverterConfig, cloudProfile gardener.CloudProfile) Converter {
+	return newConverter(config, &cloudProfile)
+}
+
+func newConverter(config config.ConverterConfig, cloudProfile *gardener.CloudProfile) Converter {
 	extenders := []Extend{
 		extender2.ExtendWithAnnotations,
 		extender2.ExtendWithLabels,
 		extender2.NewKubernetesExtender(config.Kubernetes.DefaultVersion),
-		extender2.NewProviderExtender(config.Provider.AWS.EnableIMDSv2, config.MachineImage.DefaultName, config.MachineImage.DefaultVersion),
+		extender2.NewProviderExtender(config.Provider.AWS.EnableIMDSv2, config.Provider.OpenStack, config.MachineImage.DefaultName, config.MachineImage.DefaultVersion, config.MachineImage.PinnedVersion, cloudProfile),
+		extender2.NewAutoscalerExtender(config.Autoscaler),
 		extender2.NewDNSExtender(config.DNS.SecretName, config.DNS.DomainPrefix, config.DNS.ProviderType),
 		extender2.NewOidcExtender(config.Kubernetes.DefaultOperatorOidc),
-		extender2.ExtendWithCloudProfile,
+		extender2.NewCloudProfileExtender(config.CloudProfile),
 		extender2.ExtendWithNetworkFilter,
 		extender2.ExtendWithCertConfig,
-		extender2.ExtendWithExposureClassName,
+		extender2.NewExposureClassNameExtender(config.Provider.OpenStack),
 		extender2.ExtendWithTolerations,
-		extender2.NewMaintenanceExtender(config.Kubernetes.EnableKubernetesVersionAutoUpdate, config.Kubernetes.EnableMachineImageVersionAutoUpdate),
+		extender2.ExtendWithHibernation,
+		extender2.NewMaintenanceExtender(config.Kubernetes.EnableKubernetesVersionAutoUpdate, config.Kubernetes.EnableMachineImageVersionAutoUpdate, config.MaintenanceWindow),
 	}
 
 	return Converter{
@@ -49,7 +60,7 @@ func (c Converter) ToShoot(runtime imv1.Runtime) (gardener.Shoot, error) {
 	shoot := gardener.Shoot{
 		ObjectMeta: v1.ObjectMeta{
 			Name:      runtime.Spec.Shoot.Name,
-			Namespace: fmt.Sprintf("garden-%s", c.config.Gardener.ProjectName),
+			Namespace: ShootNamespace(runtime, c.config.Gardener.ProjectName),
 		},
 		Spec: gardener.ShootSpec{
 			Purpose:           &runtime.Spec.Shoot.Purpose,
@@ -73,3 +84,13 @@ func (c Converter) ToShoot(runtime imv1.Runtime) (gardener.Shoot, error) {
 
 	return shoot, nil
 }
+
+// ShootNamespace returns the namespace of the Gardener project selected in the Runtime, or of the default project
+func ShootNamespace(runtime imv1.Runtime, defaultProjectName string) string {
+	projectName := runtime.Spec.Shoot.ProjectName
+	if projectName == "" {
+		projectName = defaultProjectName
+	}
+
+	return fmt.Sprintf("garden-%s", projectName)
+}
@@ -35,6 +35,21 @@ func TestConverter(t *testing.T) {
 		assert.Equal(t, runtime.Spec.Shoot.Networking.Nodes, *shoot.Spec.Networking.Nodes)
 		assert.Equal(t, runtime.Spec.Shoot.Networking.Pods, *shoot.Spec.Networking.Pods)
 		assert.Equal(t, runtime.Spec.Shoot.Networking.Services, *shoot.Spec.Networking.Services)
+		assert.Equal(t, fmt.Sprintf("garden-%s", converterConfig.Gardener.ProjectName), shoot.Namespace)
+	})
+
+	t.Run("Create shoot in the project selected in Runtime", func(t *testing.T) {
+		// given
+		runtime := fixRuntime()
+		runtime.Spec.Shoot.ProjectName = "kyma-additional"
+		converter := NewConverter(fixConverterConfig())
+
+		// when
+		shoot, err := converter.ToShoot(runtime)
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, "garden-kyma-additional", shoot.Namespace)
 	})
 }
 
@@ -190,6 +205,14 @@ var testReader io.Reader = strings.NewReader(
 	  "auditLogging": {
 		"policyConfigMapName": "test-policy",
 		"tenantConfigPath": "test-path"
+	  },
+	  "maintenanceWindow": {
+		"windowsByRegion": {
+		  "eu-central-1": {
+			"begin": "030000+0000",
+			"end": "040000+0000"
+		  }
+		}
 	  }
 }
 }`)
@@ -249,6 +272,14 @@ func Test_ConverterConfig_Load_OK(t *testing.T) {
 				PolicyConfigMapName: "test-policy",
 				TenantConfigPath:    "test-path",
 			},
+			MaintenanceWindow: config.MaintenanceWindowConfig{
+				WindowsByRegion: map[string]config.MaintenanceTimeWindow{
+					"eu-central-1": {
+						Begin: "030000+0000",
+						End:   "040000+0000",
+					},
+				},
+			},
 		},
 	}
 	assert.Equal(t, expected, cfg)
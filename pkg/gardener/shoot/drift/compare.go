@@ -0,0 +1,65 @@
+package drift
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+
+	gardener "github.com/gardener/gardener/pkg/apis/core/v1beta1"
+)
+
+// Difference is a field of the desired shoot which differs from the live shoot
+type Difference struct {
+	Path    string
+	Desired string
+	Live    string
+}
+
+func (d Difference) String() string {
+	return fmt.Sprintf("%s: expected %s, got %s", d.Path, d.Desired, d.Live)
+}
+
+// Compare returns the differences between the desired and the live shoot, using the same rules as the shoot comparator.
+// The live shoot is the expected one, as in the comparison done by the runtime migrator, so the extensions, tolerations
+// and worker pools existing only in the live shoot are not reported
+func Compare(desired, live gardener.Shoot) ([]Difference, error) {
+	failed, err := mismatches(shootMatchers(live, desired))
+	if err != nil {
+		return nil, err
+	}
+
+	differences := make([]Difference, 0, len(failed))
+	for _, matcher := range failed {
+		differences = append(differences, Difference{
+			Path:    matcher.path,
+			Desired: format(matcher.actual),
+			Live:    format(matcher.expected),
+		})
+	}
+
+	return differences, nil
+}
+
+func format(value any) string {
+	if value == nil {
+		return "<nil>"
+	}
+
+	if v := reflect.ValueOf(value); v.Kind() == reflect.Pointer {
+		if v.IsNil() {
+			return "<nil>"
+		}
+		value = v.Elem().Interface()
+	}
+
+	if str, ok := value.(string); ok {
+		return str
+	}
+
+	bytes, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Sprintf("%v", value)
+	}
+
+	return string(bytes)
+}
@@ -0,0 +1,139 @@
+package drift
+
+import (
+	"testing"
+
+	gardener "github.com/gardener/gardener/pkg/apis/core/v1beta1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/utils/ptr"
+)
+
+func TestCompare(t *testing.T) {
+	for tname, testCase := range map[string]struct {
+		modifyLive    func(*gardener.Shoot)
+		expectedPaths []string
+	}{
+		"Report no differences for equal shoots": {
+			modifyLive: func(_ *gardener.Shoot) {},
+		},
+		"Ignore fields and list elements set only in live shoot": {
+			modifyLive: func(shoot *gardener.Shoot) {
+				shoot.Spec.Provider.Workers[0].Machine.Architecture = ptr.To("amd64")
+				shoot.Spec.Provider.Workers = append(shoot.Spec.Provider.Workers, gardener.Worker{Name: "additional"})
+				shoot.Spec.Extensions = append(shoot.Spec.Extensions, gardener.Extension{Type: "shoot-dns-service"})
+			},
+		},
+		"Ignore order of provider config fields": {
+			modifyLive: func(shoot *gardener.Shoot) {
+				shoot.Spec.Provider.InfrastructureConfig = &runtime.RawExtension{Raw: []byte(`{"networks":{"vpc":{"cidr":"10.250.0.0/16"}},"kind":"InfrastructureConfig"}`)}
+			},
+		},
+		"Report fields and labels set only in live shoot": {
+			modifyLive: func(shoot *gardener.Shoot) {
+				shoot.Labels["shoot.gardener.cloud/status"] = "healthy"
+				shoot.Spec.Provider.Workers[0].MaxSurge = ptr.To(intstr.FromInt32(1))
+				shoot.Spec.Provider.Workers[0].Zones = append(shoot.Spec.Provider.Workers[0].Zones, "eu-central-1b")
+			},
+			expectedPaths: []string{
+				"metadata/labels/shoot.gardener.cloud/status",
+				"spec/provider/workers/worker/maxSurge",
+				"spec/provider/workers/worker/zones",
+			},
+		},
+		"Report changed fields": {
+			modifyLive: func(shoot *gardener.Shoot) {
+				shoot.Spec.Kubernetes.Version = "1.30"
+				shoot.Spec.Provider.Workers[0].Maximum = 10
+				shoot.Spec.Provider.InfrastructureConfig = &runtime.RawExtension{Raw: []byte(`{"kind":"InfrastructureConfig","networks":{"vpc":{"cidr":"10.180.0.0/16"}}}`)}
+				shoot.Labels["account"] = "other"
+			},
+			expectedPaths: []string{
+				"metadata/labels/account",
+				"spec/kubernetes/version",
+				"spec/provider/infrastructureConfig",
+				"spec/provider/workers/worker/maximum",
+			},
+		},
+		"Report changed cluster autoscaler and maintenance time window": {
+			modifyLive: func(shoot *gardener.Shoot) {
+				shoot.Spec.Kubernetes.ClusterAutoscaler.Expander = ptr.To(gardener.ClusterAutoscalerExpanderRandom)
+				shoot.Spec.Maintenance.TimeWindow = &gardener.MaintenanceTimeWindow{Begin: "010000+0000", End: "020000+0000"}
+			},
+			expectedPaths: []string{
+				"spec/kubernetes/clusterAutoscaler",
+				"spec/maintenance/timeWindow",
+			},
+		},
+		"Report missing worker and extension": {
+			modifyLive: func(shoot *gardener.Shoot) {
+				shoot.Spec.Provider.Workers[0].Name = "other"
+				shoot.Spec.Extensions = nil
+			},
+			expectedPaths: []string{
+				"spec/extensions/shoot-networking-filter",
+				"spec/provider/workers/worker",
+			},
+		},
+	} {
+		t.Run(tname, func(t *testing.T) {
+			// given
+			desired := fixShoot()
+			live := fixShoot()
+			testCase.modifyLive(&live)
+
+			// when
+			differences, err := Compare(desired, live)
+
+			// then
+			require.NoError(t, err)
+			var actualPaths []string
+			for _, difference := range differences {
+				actualPaths = append(actualPaths, difference.Path)
+			}
+			assert.ElementsMatch(t, testCase.expectedPaths, actualPaths)
+		})
+	}
+}
+
+func fixShoot() gardener.Shoot {
+	shoot := gardener.Shoot{
+		Spec: gardener.ShootSpec{
+			Region: "eu-central-1",
+			Kubernetes: gardener.Kubernetes{
+				Version:                     "1.29",
+				EnableStaticTokenKubeconfig: ptr.To(false),
+				ClusterAutoscaler: &gardener.ClusterAutoscaler{
+					Expander: ptr.To(gardener.ClusterAutoscalerExpanderLeastWaste),
+				},
+			},
+			Maintenance: &gardener.Maintenance{
+				AutoUpdate: &gardener.MaintenanceAutoUpdate{KubernetesVersion: false},
+				TimeWindow: &gardener.MaintenanceTimeWindow{Begin: "220000+0000", End: "230000+0000"},
+			},
+			Extensions: []gardener.Extension{
+				{Type: "shoot-networking-filter", Disabled: ptr.To(true)},
+			},
+			Provider: gardener.Provider{
+				Type:                 "aws",
+				InfrastructureConfig: &runtime.RawExtension{Raw: []byte(`{"kind":"InfrastructureConfig","networks":{"vpc":{"cidr":"10.250.0.0/16"}}}`)},
+				Workers: []gardener.Worker{
+					{
+						Name:    "worker",
+						Machine: gardener.Machine{Type: "m6i.large"},
+						Minimum: 1,
+						Maximum: 3,
+						Zones:   []string{"eu-central-1a"},
+					},
+				},
+			},
+		},
+	}
+	shoot.Name = "shoot"
+	shoot.Namespace = "garden-kyma"
+	shoot.Labels = map[string]string{"account": "global-account-id"}
+
+	return shoot
+}
@@ -1,17 +1,16 @@
-package utilz
+package drift
 
 import (
 	"fmt"
 	"reflect"
 
-	"github.com/kyma-project/infrastructure-manager/hack/shoot-comparator/pkg/errors"
 	"sigs.k8s.io/yaml"
 )
 
-func Get[T any](v interface{}) (T, error) {
+func get[T any](v interface{}) (T, error) {
 	var result T
 	if v == nil {
-		return result, errors.ErrNilValue
+		return result, ErrNilValue
 	}
 
 	switch typedV := v.(type) {
@@ -29,6 +28,6 @@ func Get[T any](v interface{}) (T, error) {
 		return *typedV, nil
 
 	default:
-		return result, fmt.Errorf(`%w: %s`, errors.ErrInvalidType, reflect.TypeOf(typedV))
+		return result, fmt.Errorf(`%w: %s`, ErrInvalidType, reflect.TypeOf(typedV))
 	}
 }
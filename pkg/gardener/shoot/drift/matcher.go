@@ -0,0 +1,311 @@
+package drift
+
+import (
+	"fmt"
+	"reflect"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/gardener/gardener/pkg/apis/core/v1beta1"
+	"github.com/onsi/gomega"
+	"github.com/onsi/gomega/types"
+	"sigs.k8s.io/yaml"
+)
+
+type Matcher struct {
+	toMatch interface{}
+	fails   []string
+}
+
+func NewMatcher(i interface{}) types.GomegaMatcher {
+	return &Matcher{
+		toMatch: i,
+	}
+}
+
+func getShoot(i interface{}) (shoot v1beta1.Shoot, err error) {
+	if i == nil {
+		return v1beta1.Shoot{}, fmt.Errorf("invalid value nil")
+	}
+
+	switch v := i.(type) {
+	case string:
+		err = yaml.Unmarshal([]byte(v), &shoot)
+		return shoot, err
+
+	case v1beta1.Shoot:
+		return v, nil
+
+	case *v1beta1.Shoot:
+		return *v, nil
+
+	default:
+		return v1beta1.Shoot{}, fmt.Errorf(`%w: %s`, ErrInvalidType, reflect.TypeOf(v))
+	}
+}
+
+func (m *Matcher) Match(actual interface{}) (success bool, err error) {
+	shootActual, err := getShoot(actual)
+	if err != nil {
+		return false, err
+	}
+
+	shootToMatch, err := getShoot(m.toMatch)
+	if err != nil {
+		return false, err
+	}
+
+	failed, err := mismatches(shootMatchers(shootToMatch, shootActual))
+	if err != nil {
+		return false, err
+	}
+
+	for _, matcher := range failed {
+		m.fails = append(m.fails, matcher.failureMessage())
+	}
+
+	return len(m.fails) == 0, nil
+}
+
+func (m *Matcher) NegatedFailureMessage(_ interface{}) string {
+	return "expected should not equal actual"
+}
+
+func (m *Matcher) FailureMessage(_ interface{}) string {
+	return strings.Join(m.fails, "\n")
+}
+
+// propertyMatcher matches a single field of the shoot, the path of the field is reported for the failed match
+type propertyMatcher struct {
+	types.GomegaMatcher
+	path     string
+	expected interface{}
+	actual   interface{}
+}
+
+func (m propertyMatcher) failureMessage() string {
+	return fmt.Sprintf("%s: %s", m.path, m.FailureMessage(m.actual))
+}
+
+func mismatches(matchers []propertyMatcher) ([]propertyMatcher, error) {
+	var failed []propertyMatcher
+	for _, matcher := range matchers {
+		ok, err := matcher.Match(matcher.actual)
+		if err != nil {
+			return nil, err
+		}
+
+		if !ok {
+			failed = append(failed, matcher)
+		}
+	}
+
+	return failed, nil
+}
+
+// shootMatchers returns the rules comparing the shoot with the expected one, the fields not listed here are ignored
+func shootMatchers(expected, actual v1beta1.Shoot) []propertyMatcher {
+	matchers := []propertyMatcher{
+		equal("metadata/name", expected.Name, actual.Name),
+		equal("metadata/namespace", expected.Namespace, actual.Namespace),
+	}
+
+	matchers = append(matchers, elementMatchers("spec/extensions", expected.Spec.Extensions, actual.Spec.Extensions, idExtension, extensionMatchers)...)
+	matchers = append(matchers, equal("spec/cloudProfileName", expected.Spec.CloudProfileName, actual.Spec.CloudProfileName))
+	matchers = append(matchers, kubernetesMatchers("spec/kubernetes", expected.Spec.Kubernetes, actual.Spec.Kubernetes)...)
+	matchers = append(matchers, pointerMatchers("spec/networking", expected.Spec.Networking, actual.Spec.Networking, networkingMatchers)...)
+	matchers = append(matchers, pointerMatchers("spec/maintenance", expected.Spec.Maintenance, actual.Spec.Maintenance, maintenanceMatchers)...)
+	matchers = append(matchers,
+		equal("spec/purpose", expected.Spec.Purpose, actual.Spec.Purpose),
+		equal("spec/region", expected.Spec.Region, actual.Spec.Region),
+		equal("spec/secretBindingName", expected.Spec.SecretBindingName, actual.Spec.SecretBindingName),
+	)
+	matchers = append(matchers, pointerMatchers("spec/dns", expected.Spec.DNS, actual.Spec.DNS, dnsMatchers)...)
+	matchers = append(matchers, elementMatchers("spec/tolerations", expected.Spec.Tolerations, actual.Spec.Tolerations, idToleration, tolerationMatchers)...)
+	matchers = append(matchers,
+		equal("spec/exposureClassName", expected.Spec.ExposureClassName, actual.Spec.ExposureClassName),
+		equal("spec/controlPlane", expected.Spec.ControlPlane, actual.Spec.ControlPlane),
+	)
+	matchers = append(matchers, providerMatchers("spec/provider", expected.Spec.Provider, actual.Spec.Provider)...)
+	matchers = append(matchers, labelMatchers("metadata/labels", expected.Labels, actual.Labels)...)
+
+	return matchers
+}
+
+func equal(path string, expected, actual interface{}) propertyMatcher {
+	return propertyMatcher{
+		GomegaMatcher: gomega.BeComparableTo(expected),
+		path:          path,
+		expected:      expected,
+		actual:        actual,
+	}
+}
+
+// pointerMatchers requires the nil value if the expected value is nil, otherwise the fields of both values are matched
+func pointerMatchers[T any](path string, expected, actual *T, fields func(path string, expected, actual T) []propertyMatcher) []propertyMatcher {
+	if expected == nil {
+		return []propertyMatcher{{GomegaMatcher: gomega.BeNil(), path: path, expected: expected, actual: actual}}
+	}
+
+	if actual == nil {
+		return []propertyMatcher{{GomegaMatcher: gomega.Not(gomega.BeNil()), path: path, expected: expected, actual: actual}}
+	}
+
+	return fields(path, *expected, *actual)
+}
+
+// optionalMatchers matches the field only if it is set in the actual shoot, the field left unset is chosen by Gardener
+func optionalMatchers[T any](path string, expected, actual *T) []propertyMatcher {
+	if actual == nil {
+		return nil
+	}
+
+	return []propertyMatcher{equal(path, expected, actual)}
+}
+
+// elementMatchers matches each element of the actual list with the expected element of the same ID,
+// the expected elements missing in the actual list are skipped
+func elementMatchers[T any](path string, expected, actual []T, id func(T) string, fields func(path string, expected, actual T) []propertyMatcher) []propertyMatcher {
+	var matchers []propertyMatcher
+	var found []string
+
+	for _, element := range actual {
+		elementID := id(element)
+		elementPath := fmt.Sprintf("%s/%s", path, elementID)
+
+		index := slices.IndexFunc(expected, func(e T) bool { return id(e) == elementID })
+		if index < 0 || slices.Contains(found, elementID) {
+			matchers = append(matchers, propertyMatcher{GomegaMatcher: gomega.BeNil(), path: elementPath, actual: element})
+			continue
+		}
+
+		found = append(found, elementID)
+		matchers = append(matchers, fields(elementPath, expected[index], element)...)
+	}
+
+	return matchers
+}
+
+func labelMatchers(path string, expected, actual map[string]string) []propertyMatcher {
+	if len(expected) == 0 {
+		return []propertyMatcher{{GomegaMatcher: gomega.BeEmpty(), path: path, expected: expected, actual: actual}}
+	}
+
+	keys := make([]string, 0, len(expected))
+	for key := range expected {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	matchers := make([]propertyMatcher, 0, len(keys))
+	for _, key := range keys {
+		var value interface{}
+		if actualValue, ok := actual[key]; ok {
+			value = actualValue
+		}
+		matchers = append(matchers, equal(fmt.Sprintf("%s/%s", path, key), expected[key], value))
+	}
+
+	return matchers
+}
+
+func val(v interface{}) string {
+	if reflect.ValueOf(v).Kind() == reflect.Pointer && reflect.ValueOf(v).IsNil() {
+		return ""
+	}
+
+	if reflect.ValueOf(v).Kind() == reflect.Pointer {
+		return fmt.Sprintf("%v", reflect.ValueOf(v).Elem())
+	}
+
+	return fmt.Sprintf("%v", v)
+}
+
+func idToleration(toleration v1beta1.Toleration) string {
+	return fmt.Sprintf("%s:%s", toleration.Key, val(toleration.Value))
+}
+
+func tolerationMatchers(path string, expected, actual v1beta1.Toleration) []propertyMatcher {
+	return []propertyMatcher{
+		equal(path+"/key", expected.Key, actual.Key),
+		equal(path+"/value", expected.Value, actual.Value),
+	}
+}
+
+func idProvider(provider v1beta1.DNSProvider) string {
+	return fmt.Sprintf("%s:%s:%s",
+		val(provider.Type),
+		val(provider.SecretName),
+		val(provider.Primary))
+}
+
+func dnsProviderMatchers(path string, expected, actual v1beta1.DNSProvider) []propertyMatcher {
+	matchers := []propertyMatcher{
+		{GomegaMatcher: gomega.Equal(expected.Primary), path: path + "/primary", expected: expected.Primary, actual: actual.Primary},
+		{GomegaMatcher: gomega.Equal(expected.SecretName), path: path + "/secretName", expected: expected.SecretName, actual: actual.SecretName},
+		{GomegaMatcher: gomega.Equal(expected.Type), path: path + "/type", expected: expected.Type, actual: actual.Type},
+	}
+
+	return append(matchers, pointerMatchers(path+"/domains", expected.Domains, actual.Domains, func(path string, expected, actual v1beta1.DNSIncludeExclude) []propertyMatcher {
+		return []propertyMatcher{equal(path+"/include", expected.Include, actual.Include)}
+	})...)
+}
+
+func dnsMatchers(path string, expected, actual v1beta1.DNS) []propertyMatcher {
+	matchers := []propertyMatcher{equal(path+"/domain", expected.Domain, actual.Domain)}
+
+	return append(matchers, elementMatchers(path+"/providers", expected.Providers, actual.Providers, idProvider, dnsProviderMatchers)...)
+}
+
+func maintenanceMatchers(path string, expected, actual v1beta1.Maintenance) []propertyMatcher {
+	return append([]propertyMatcher{equal(path+"/autoUpdate", expected.AutoUpdate, actual.AutoUpdate)},
+		optionalMatchers(path+"/timeWindow", expected.TimeWindow, actual.TimeWindow)...)
+}
+
+func networkingMatchers(path string, expected, actual v1beta1.Networking) []propertyMatcher {
+	return []propertyMatcher{
+		equal(path+"/type", expected.Type, actual.Type),
+		equal(path+"/nodes", expected.Nodes, actual.Nodes),
+		equal(path+"/pods", expected.Pods, actual.Pods),
+		equal(path+"/services", expected.Services, actual.Services),
+	}
+}
+
+func kubernetesMatchers(path string, expected, actual v1beta1.Kubernetes) []propertyMatcher {
+	matchers := []propertyMatcher{
+		equal(path+"/version", expected.Version, actual.Version),
+		equal(path+"/enableStaticTokenKubeconfig", expected.EnableStaticTokenKubeconfig, actual.EnableStaticTokenKubeconfig),
+	}
+
+	matchers = append(matchers, optionalMatchers(path+"/clusterAutoscaler", expected.ClusterAutoscaler, actual.ClusterAutoscaler)...)
+
+	return append(matchers, pointerMatchers(path+"/kubeAPIServer", expected.KubeAPIServer, actual.KubeAPIServer, func(path string, expected, actual v1beta1.KubeAPIServerConfig) []propertyMatcher {
+		return pointerMatchers(path+"/oidcConfig", expected.OIDCConfig, actual.OIDCConfig, oidcConfigMatchers)
+	})...)
+}
+
+func oidcConfigMatchers(path string, expected, actual v1beta1.OIDCConfig) []propertyMatcher {
+	return []propertyMatcher{
+		equal(path+"/caBundle", expected.CABundle, actual.CABundle),
+		equal(path+"/clientID", expected.ClientID, actual.ClientID),
+		equal(path+"/groupsClaim", expected.GroupsClaim, actual.GroupsClaim),
+		equal(path+"/groupsPrefix", expected.GroupsPrefix, actual.GroupsPrefix),
+		equal(path+"/issuerURL", expected.IssuerURL, actual.IssuerURL),
+		equal(path+"/requiredClaims", expected.RequiredClaims, actual.RequiredClaims),
+		{GomegaMatcher: gomega.ContainElements(expected.SigningAlgs), path: path + "/signingAlgs", expected: expected.SigningAlgs, actual: actual.SigningAlgs},
+		equal(path+"/usernameClaim", expected.UsernameClaim, actual.UsernameClaim),
+		equal(path+"/usernamePrefix", expected.UsernamePrefix, actual.UsernamePrefix),
+	}
+}
+
+func idExtension(e v1beta1.Extension) string {
+	return e.Type
+}
+
+func extensionMatchers(path string, expected, actual v1beta1.Extension) []propertyMatcher {
+	return []propertyMatcher{
+		equal(path+"/providerConfig", expected.ProviderConfig, actual.ProviderConfig),
+		equal(path+"/disabled", expected.Disabled, actual.Disabled),
+	}
+}
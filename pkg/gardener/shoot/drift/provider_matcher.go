@@ -0,0 +1,114 @@
+package drift
+
+import (
+	"strings"
+
+	"github.com/gardener/gardener/pkg/apis/core/v1beta1"
+	"github.com/onsi/gomega"
+	"github.com/onsi/gomega/types"
+)
+
+func NewProviderMatcher(v any, path string) types.GomegaMatcher {
+	return &ProviderMatcher{
+		toMatch:  v,
+		rootPath: path,
+	}
+}
+
+type ProviderMatcher struct {
+	toMatch  interface{}
+	fails    []string
+	rootPath string
+}
+
+func (m *ProviderMatcher) Match(actual interface{}) (success bool, err error) {
+	providerActual, err := get[v1beta1.Provider](actual)
+	if err != nil {
+		return false, err
+	}
+
+	providerToMatch, err := get[v1beta1.Provider](m.toMatch)
+	if err != nil {
+		return false, err
+	}
+
+	failed, err := mismatches(providerMatchers(m.rootPath, providerToMatch, providerActual))
+	if err != nil {
+		return false, err
+	}
+
+	for _, matcher := range failed {
+		m.fails = append(m.fails, matcher.failureMessage())
+	}
+
+	return len(m.fails) == 0, nil
+}
+
+func (m *ProviderMatcher) NegatedFailureMessage(_ interface{}) string {
+	return "expected should not equal actual"
+}
+
+func (m *ProviderMatcher) FailureMessage(_ interface{}) string {
+	return strings.Join(m.fails, "\n")
+}
+
+func providerMatchers(path string, expected, actual v1beta1.Provider) []propertyMatcher {
+	matchers := []propertyMatcher{equal(path+"/type", expected.Type, actual.Type)}
+
+	matchers = append(matchers, elementMatchers(path+"/workers", expected.Workers, actual.Workers, idWorker, workerMatchers)...)
+	matchers = append(matchers,
+		rawExtension(path+"/controlPlaneConfig", expected.ControlPlaneConfig, actual.ControlPlaneConfig),
+		rawExtension(path+"/infrastructureConfig", expected.InfrastructureConfig, actual.InfrastructureConfig),
+	)
+
+	return append(matchers, workersSettingsMatchers(path+"/workersSettings", expected.WorkersSettings, actual.WorkersSettings)...)
+}
+
+func rawExtension(path string, expected, actual interface{}) propertyMatcher {
+	return propertyMatcher{
+		GomegaMatcher: NewRawExtensionMatcher(expected),
+		path:          path,
+		expected:      expected,
+		actual:        actual,
+	}
+}
+
+func idWorker(w v1beta1.Worker) string {
+	return w.Name
+}
+
+func workerMatchers(path string, expected, actual v1beta1.Worker) []propertyMatcher {
+	matchers := []propertyMatcher{
+		equal(path+"/name", expected.Name, actual.Name),
+		equal(path+"/machine/type", expected.Machine.Type, actual.Machine.Type),
+	}
+
+	matchers = append(matchers, pointerMatchers(path+"/machine/image", expected.Machine.Image, actual.Machine.Image, func(path string, expected, actual v1beta1.ShootMachineImage) []propertyMatcher {
+		return []propertyMatcher{
+			equal(path+"/name", expected.Name, actual.Name),
+			equal(path+"/version", expected.Version, actual.Version),
+		}
+	})...)
+
+	return append(matchers,
+		equal(path+"/maximum", expected.Maximum, actual.Maximum),
+		equal(path+"/minimum", expected.Minimum, actual.Minimum),
+		equal(path+"/maxSurge", expected.MaxSurge, actual.MaxSurge),
+		equal(path+"/maxUnavailable", expected.MaxUnavailable, actual.MaxUnavailable),
+		rawExtension(path+"/providerConfig", expected.ProviderConfig, actual.ProviderConfig),
+		equal(path+"/volume", expected.Volume, actual.Volume),
+		propertyMatcher{GomegaMatcher: gomega.ContainElements(expected.Zones), path: path + "/zones", expected: expected.Zones, actual: actual.Zones},
+	)
+}
+
+func workersSettingsMatchers(path string, expected, actual *v1beta1.WorkersSettings) []propertyMatcher {
+	if expected == nil || expected.SSHAccess == nil {
+		return []propertyMatcher{{GomegaMatcher: gomega.BeNil(), path: path, expected: expected, actual: actual}}
+	}
+
+	return pointerMatchers(path, expected, actual, func(path string, expected, actual v1beta1.WorkersSettings) []propertyMatcher {
+		return pointerMatchers(path+"/sshAccess", expected.SSHAccess, actual.SSHAccess, func(path string, expected, actual v1beta1.SSHAccess) []propertyMatcher {
+			return []propertyMatcher{equal(path+"/enabled", expected.Enabled, actual.Enabled)}
+		})
+	})
+}
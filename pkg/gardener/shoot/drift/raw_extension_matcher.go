@@ -1,9 +1,8 @@
-package runtime
+package drift
 
 import (
 	"sort"
 
-	"github.com/kyma-project/infrastructure-manager/hack/shoot-comparator/pkg/utilz"
 	"github.com/onsi/gomega"
 	"github.com/onsi/gomega/types"
 	"k8s.io/apimachinery/pkg/runtime"
@@ -24,12 +23,12 @@ func (m *RawExtensionMatcher) Match(actual interface{}) (bool, error) {
 		return true, nil
 	}
 
-	rawXtActual, err := utilz.Get[runtime.RawExtension](actual)
+	rawXtActual, err := get[runtime.RawExtension](actual)
 	if err != nil {
 		return false, err
 	}
 
-	rawXtToMatch, err := utilz.Get[runtime.RawExtension](m.toMatch)
+	rawXtToMatch, err := get[runtime.RawExtension](m.toMatch)
 	if err != nil {
 		return false, err
 	}
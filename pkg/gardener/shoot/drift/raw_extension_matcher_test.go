@@ -1,4 +1,4 @@
-package runtime
+package drift
 
 import (
 	"os"
@@ -47,6 +47,18 @@ func TestMatcher(t *testing.T) {
 			isOK:     false,
 			hasErr:   false,
 		},
+		{
+			actual:   string(loadTestdata(t, "testdata/infra_cfg_alicloud_11.yaml")),
+			expected: string(loadTestdata(t, "testdata/infra_cfg_alicloud_12.yaml")),
+			isOK:     true,
+			hasErr:   false,
+		},
+		{
+			actual:   string(loadTestdata(t, "testdata/infra_cfg_alicloud_11.yaml")),
+			expected: string(loadTestdata(t, "testdata/infra_cfg_alicloud_21.yaml")),
+			isOK:     false,
+			hasErr:   false,
+		},
 		{
 			actual:   []byte("invalid type"),
 			expected: []byte("invalid type"),
@@ -0,0 +1,80 @@
+package drift
+
+import (
+	"bytes"
+	"fmt"
+	"slices"
+
+	gardener "github.com/gardener/gardener/pkg/apis/core/v1beta1"
+	"sigs.k8s.io/structured-merge-diff/v4/fieldpath"
+	"sigs.k8s.io/structured-merge-diff/v4/value"
+)
+
+// Removed returns the extensions, tolerations and worker pools of the live shoot which are missing in the desired shoot
+// and are managed by the given field manager, as Compare does not report them. The elements set only by the other
+// managers are kept by the server-side apply, so they are not reported either
+func Removed(desired, live gardener.Shoot, manager string) ([]Difference, error) {
+	owned, err := managedFields(live, manager)
+	if err != nil {
+		return nil, err
+	}
+
+	differences := removedElements("spec/extensions", desired.Spec.Extensions, live.Spec.Extensions, idExtension, func(e gardener.Extension) bool {
+		return owned.isManaged(fieldpath.MakePathOrDie("spec", "extensions"), fieldpath.KeyByFields("type", e.Type))
+	})
+	differences = append(differences, removedElements("spec/tolerations", desired.Spec.Tolerations, live.Spec.Tolerations, idToleration, func(t gardener.Toleration) bool {
+		return owned.isManaged(fieldpath.MakePathOrDie("spec", "tolerations"), fieldpath.KeyByFields("key", t.Key))
+	})...)
+
+	return append(differences, removedElements("spec/provider/workers", desired.Spec.Provider.Workers, live.Spec.Provider.Workers, idWorker, func(w gardener.Worker) bool {
+		return owned.isManaged(fieldpath.MakePathOrDie("spec", "provider", "workers"), fieldpath.KeyByFields("name", w.Name))
+	})...), nil
+}
+
+func removedElements[T any](path string, desired, live []T, id func(T) string, managed func(T) bool) []Difference {
+	var differences []Difference
+
+	for _, element := range live {
+		elementID := id(element)
+		if slices.ContainsFunc(desired, func(e T) bool { return id(e) == elementID }) || !managed(element) {
+			continue
+		}
+
+		differences = append(differences, Difference{
+			Path:    fmt.Sprintf("%s/%s", path, elementID),
+			Desired: format(nil),
+			Live:    format(element),
+		})
+	}
+
+	return differences
+}
+
+type fieldSet struct {
+	*fieldpath.Set
+}
+
+// managedFields returns the fields of the shoot applied by the given field manager
+func managedFields(shoot gardener.Shoot, manager string) (fieldSet, error) {
+	fields := &fieldpath.Set{}
+
+	for _, entry := range shoot.ManagedFields {
+		if entry.Manager != manager || entry.FieldsV1 == nil {
+			continue
+		}
+
+		set := &fieldpath.Set{}
+		if err := set.FromJSON(bytes.NewReader(entry.FieldsV1.Raw)); err != nil {
+			return fieldSet{}, fmt.Errorf("%w: managed fields of %s: %v", ErrInvalidValue, manager, err)
+		}
+		fields = fields.Union(set)
+	}
+
+	return fieldSet{fields}, nil
+}
+
+// isManaged returns true if the list element, or the whole list for the atomic lists, is managed
+func (s fieldSet) isManaged(list fieldpath.Path, key *value.FieldList) bool {
+	element := append(fieldpath.Path{}, list...)
+	return s.Has(list) || s.Has(append(element, fieldpath.PathElement{Key: key}))
+}
@@ -12,6 +12,9 @@ import (
 //- kcp.provisioner.kyma-project.io/runtime-id
 //- support.gardener.cloud/eu-access-for-cluster-nodes
 
+// ShootMetadataPrefix is the prefix of the annotations and labels owned by the infrastructure manager
+const ShootMetadataPrefix = "infrastructuremanager.kyma-project.io/"
+
 const (
 	ShootRuntimeGenerationAnnotation  = "infrastructuremanager.kyma-project.io/runtime-generation"
 	ShootRuntimeIDAnnotation          = "infrastructuremanager.kyma-project.io/runtime-id"
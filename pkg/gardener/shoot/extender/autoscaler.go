@@ -0,0 +1,137 @@
+package extender
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+
+	gardener "github.com/gardener/gardener/pkg/apis/core/v1beta1"
+	imv1 "github.com/kyma-project/infrastructure-manager/api/v1"
+	"github.com/kyma-project/infrastructure-manager/pkg/config"
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/utils/ptr"
+)
+
+// NewAutoscalerExtender creates the extender applying the cluster autoscaler settings and the rolling update settings of the workers.
+// The defaults come from the converter config, the settings from the Runtime take precedence.
+// It must be called after the provider extender as the workers are validated after the defaults are applied
+func NewAutoscalerExtender(cfg config.AutoscalerConfig) func(runtime imv1.Runtime, shoot *gardener.Shoot) error {
+	return func(runtime imv1.Runtime, shoot *gardener.Shoot) error {
+		clusterAutoscaler, err := mergeClusterAutoscaler(cfg.ClusterAutoscaler, runtime.Spec.Shoot.Kubernetes.ClusterAutoscaler)
+		if err != nil {
+			return errors.Wrap(err, "failed to merge cluster autoscaler settings")
+		}
+		shoot.Spec.Kubernetes.ClusterAutoscaler = clusterAutoscaler
+
+		for i := 0; i < len(shoot.Spec.Provider.Workers); i++ {
+			worker := &shoot.Spec.Provider.Workers[i]
+
+			if worker.MaxSurge == nil && cfg.WorkerMaxSurge != nil {
+				worker.MaxSurge = ptr.To(*cfg.WorkerMaxSurge)
+			}
+			if worker.MaxUnavailable == nil && cfg.WorkerMaxUnavailable != nil {
+				worker.MaxUnavailable = ptr.To(*cfg.WorkerMaxUnavailable)
+			}
+
+			if err := ValidateWorker(*worker); err != nil {
+				return errors.Wrapf(err, "invalid worker %s", worker.Name)
+			}
+		}
+
+		return nil
+	}
+}
+
+// mergeClusterAutoscaler returns nil if no settings are configured, so the Gardener defaults are used
+func mergeClusterAutoscaler(defaults gardener.ClusterAutoscaler, overrides *gardener.ClusterAutoscaler) (*gardener.ClusterAutoscaler, error) {
+	if overrides == nil {
+		if reflect.ValueOf(defaults).IsZero() {
+			return nil, nil
+		}
+		return &defaults, nil
+	}
+
+	defaultsMap, err := toJSONObject(defaults)
+	if err != nil {
+		return nil, err
+	}
+	overridesMap, err := toJSONObject(*overrides)
+	if err != nil {
+		return nil, err
+	}
+
+	mergedBytes, err := json.Marshal(mergeJSONObjects(defaultsMap, overridesMap))
+	if err != nil {
+		return nil, err
+	}
+
+	var merged gardener.ClusterAutoscaler
+	if err := json.Unmarshal(mergedBytes, &merged); err != nil {
+		return nil, err
+	}
+
+	return &merged, nil
+}
+
+func toJSONObject(value any) (map[string]any, error) {
+	valueBytes, err := json.Marshal(value)
+	if err != nil {
+		return nil, err
+	}
+
+	var object map[string]any
+	err = json.Unmarshal(valueBytes, &object)
+
+	return object, err
+}
+
+// ValidateWorker checks the scaling and the rolling update settings of the worker pool, the values which are not set are not validated
+func ValidateWorker(worker gardener.Worker) error {
+	if worker.Minimum < 0 {
+		return fmt.Errorf("minimum must not be negative, got %d", worker.Minimum)
+	}
+
+	if worker.Maximum < worker.Minimum {
+		return fmt.Errorf("maximum %d must not be lower than minimum %d", worker.Maximum, worker.Minimum)
+	}
+
+	// the machines are distributed across the zones, every zone needs at least one of them
+	if worker.Maximum > 0 && int(worker.Maximum) < len(worker.Zones) {
+		return fmt.Errorf("maximum %d must not be lower than the number of zones %d", worker.Maximum, len(worker.Zones))
+	}
+
+	maxSurge, err := validateRollingUpdateValue("maxSurge", worker.MaxSurge)
+	if err != nil {
+		return err
+	}
+
+	maxUnavailable, err := validateRollingUpdateValue("maxUnavailable", worker.MaxUnavailable)
+	if err != nil {
+		return err
+	}
+
+	if worker.MaxSurge != nil && worker.MaxUnavailable != nil && maxSurge == 0 && maxUnavailable == 0 {
+		return errors.New("maxSurge and maxUnavailable must not be both 0")
+	}
+
+	return nil
+}
+
+// validateRollingUpdateValue returns the value scaled to 100 machines, so the absolute values and the percentages can be compared to 0
+func validateRollingUpdateValue(name string, value *intstr.IntOrString) (int, error) {
+	if value == nil {
+		return 0, nil
+	}
+
+	scaled, err := intstr.GetScaledValueFromIntOrPercent(value, 100, true)
+	if err != nil {
+		return 0, fmt.Errorf("invalid %s: %w", name, err)
+	}
+
+	if scaled < 0 || (value.Type == intstr.String && scaled > 100) {
+		return 0, fmt.Errorf("%s must be between 0 and 100%%, got %s", name, value.String())
+	}
+
+	return scaled, nil
+}
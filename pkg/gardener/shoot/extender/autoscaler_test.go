@@ -0,0 +1,209 @@
+package extender
+
+import (
+	"testing"
+	"time"
+
+	gardener "github.com/gardener/gardener/pkg/apis/core/v1beta1"
+	imv1 "github.com/kyma-project/infrastructure-manager/api/v1"
+	"github.com/kyma-project/infrastructure-manager/pkg/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/utils/ptr"
+)
+
+func TestAutoscalerExtender(t *testing.T) {
+	for tname, testCase := range map[string]struct {
+		givenConfig               config.AutoscalerConfig
+		givenClusterAutoscaler    *gardener.ClusterAutoscaler
+		givenWorker               gardener.Worker
+		expectedClusterAutoscaler *gardener.ClusterAutoscaler
+		expectedMaxSurge          *intstr.IntOrString
+		expectedMaxUnavailable    *intstr.IntOrString
+		expectedError             bool
+	}{
+		"Keep Gardener defaults when nothing is configured": {
+			givenWorker: fixAutoscalerWorker(),
+		},
+		"Apply defaults from config": {
+			givenConfig: config.AutoscalerConfig{
+				ClusterAutoscaler: gardener.ClusterAutoscaler{
+					ScaleDownDelayAfterAdd: &metav1.Duration{Duration: 30 * time.Minute},
+					Expander:               ptr.To(gardener.ClusterAutoscalerExpanderLeastWaste),
+				},
+				WorkerMaxSurge:       ptr.To(intstr.FromInt32(3)),
+				WorkerMaxUnavailable: ptr.To(intstr.FromInt32(0)),
+			},
+			givenWorker: fixAutoscalerWorker(),
+			expectedClusterAutoscaler: &gardener.ClusterAutoscaler{
+				ScaleDownDelayAfterAdd: &metav1.Duration{Duration: 30 * time.Minute},
+				Expander:               ptr.To(gardener.ClusterAutoscalerExpanderLeastWaste),
+			},
+			expectedMaxSurge:       ptr.To(intstr.FromInt32(3)),
+			expectedMaxUnavailable: ptr.To(intstr.FromInt32(0)),
+		},
+		"Override config defaults with Runtime settings": {
+			givenConfig: config.AutoscalerConfig{
+				ClusterAutoscaler: gardener.ClusterAutoscaler{
+					ScaleDownDelayAfterAdd: &metav1.Duration{Duration: 30 * time.Minute},
+					Expander:               ptr.To(gardener.ClusterAutoscalerExpanderLeastWaste),
+				},
+				WorkerMaxSurge: ptr.To(intstr.FromInt32(3)),
+			},
+			givenClusterAutoscaler: &gardener.ClusterAutoscaler{
+				Expander:                      ptr.To(gardener.ClusterAutoscalerExpanderPriority),
+				ScaleDownDelayAfterDelete:     &metav1.Duration{Duration: time.Minute},
+				ScaleDownUnneededTime:         &metav1.Duration{Duration: 10 * time.Minute},
+				ScaleDownUtilizationThreshold: ptr.To(0.6),
+			},
+			givenWorker: func() gardener.Worker {
+				worker := fixAutoscalerWorker()
+				worker.MaxSurge = ptr.To(intstr.FromString("50%"))
+				return worker
+			}(),
+			expectedClusterAutoscaler: &gardener.ClusterAutoscaler{
+				ScaleDownDelayAfterAdd:        &metav1.Duration{Duration: 30 * time.Minute},
+				ScaleDownDelayAfterDelete:     &metav1.Duration{Duration: time.Minute},
+				ScaleDownUnneededTime:         &metav1.Duration{Duration: 10 * time.Minute},
+				ScaleDownUtilizationThreshold: ptr.To(0.6),
+				Expander:                      ptr.To(gardener.ClusterAutoscalerExpanderPriority),
+			},
+			expectedMaxSurge: ptr.To(intstr.FromString("50%")),
+		},
+		"Use Runtime settings without config defaults": {
+			givenClusterAutoscaler: &gardener.ClusterAutoscaler{
+				Expander: ptr.To(gardener.ClusterAutoscalerExpanderRandom),
+			},
+			givenWorker: fixAutoscalerWorker(),
+			expectedClusterAutoscaler: &gardener.ClusterAutoscaler{
+				Expander: ptr.To(gardener.ClusterAutoscalerExpanderRandom),
+			},
+		},
+		"Return error for worker with both max surge and max unavailable set to 0 by defaults": {
+			givenConfig: config.AutoscalerConfig{
+				WorkerMaxSurge:       ptr.To(intstr.FromInt32(0)),
+				WorkerMaxUnavailable: ptr.To(intstr.FromString("0%")),
+			},
+			givenWorker:   fixAutoscalerWorker(),
+			expectedError: true,
+		},
+		"Return error for worker with maximum lower than minimum": {
+			givenWorker: func() gardener.Worker {
+				worker := fixAutoscalerWorker()
+				worker.Minimum = 25
+				return worker
+			}(),
+			expectedError: true,
+		},
+	} {
+		t.Run(tname, func(t *testing.T) {
+			// given
+			shoot := fixEmptyGardenerShoot("test", "kcp-system")
+			shoot.Spec.Provider.Workers = []gardener.Worker{testCase.givenWorker}
+			runtime := imv1.Runtime{
+				Spec: imv1.RuntimeSpec{
+					Shoot: imv1.RuntimeShoot{
+						Kubernetes: imv1.Kubernetes{
+							ClusterAutoscaler: testCase.givenClusterAutoscaler,
+						},
+					},
+				},
+			}
+
+			// when
+			err := NewAutoscalerExtender(testCase.givenConfig)(runtime, &shoot)
+
+			// then
+			if testCase.expectedError {
+				require.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, testCase.expectedClusterAutoscaler, shoot.Spec.Kubernetes.ClusterAutoscaler)
+			assert.Equal(t, testCase.expectedMaxSurge, shoot.Spec.Provider.Workers[0].MaxSurge)
+			assert.Equal(t, testCase.expectedMaxUnavailable, shoot.Spec.Provider.Workers[0].MaxUnavailable)
+		})
+	}
+}
+
+func TestValidateWorker(t *testing.T) {
+	for tname, testCase := range map[string]struct {
+		modify        func(*gardener.Worker)
+		expectedError bool
+	}{
+		"Accept worker without rolling update settings": {
+			modify: func(_ *gardener.Worker) {},
+		},
+		"Accept worker with max surge set to 0": {
+			modify: func(worker *gardener.Worker) {
+				worker.MaxSurge = ptr.To(intstr.FromInt32(0))
+			},
+		},
+		"Accept worker with maximum 0": {
+			modify: func(worker *gardener.Worker) {
+				worker.Minimum = 0
+				worker.Maximum = 0
+			},
+		},
+		"Reject negative minimum": {
+			modify: func(worker *gardener.Worker) {
+				worker.Minimum = -1
+			},
+			expectedError: true,
+		},
+		"Reject maximum lower than number of zones": {
+			modify: func(worker *gardener.Worker) {
+				worker.Minimum = 1
+				worker.Maximum = 2
+			},
+			expectedError: true,
+		},
+		"Reject negative max unavailable": {
+			modify: func(worker *gardener.Worker) {
+				worker.MaxUnavailable = ptr.To(intstr.FromInt32(-1))
+			},
+			expectedError: true,
+		},
+		"Reject max surge above 100 percent": {
+			modify: func(worker *gardener.Worker) {
+				worker.MaxSurge = ptr.To(intstr.FromString("150%"))
+			},
+			expectedError: true,
+		},
+		"Reject invalid max surge": {
+			modify: func(worker *gardener.Worker) {
+				worker.MaxSurge = ptr.To(intstr.FromString("many"))
+			},
+			expectedError: true,
+		},
+	} {
+		t.Run(tname, func(t *testing.T) {
+			// given
+			worker := fixAutoscalerWorker()
+			testCase.modify(&worker)
+
+			// when
+			err := ValidateWorker(worker)
+
+			// then
+			if testCase.expectedError {
+				require.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+		})
+	}
+}
+
+func fixAutoscalerWorker() gardener.Worker {
+	return gardener.Worker{
+		Name:    "worker",
+		Minimum: 3,
+		Maximum: 20,
+		Zones:   []string{"eu-central-1a", "eu-central-1b", "eu-central-1c"},
+	}
+}
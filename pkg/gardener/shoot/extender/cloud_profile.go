@@ -3,6 +3,7 @@ package extender
 import (
 	gardener "github.com/gardener/gardener/pkg/apis/core/v1beta1"
 	imv1 "github.com/kyma-project/infrastructure-manager/api/v1"
+	"github.com/kyma-project/infrastructure-manager/pkg/config"
 	"github.com/kyma-project/infrastructure-manager/pkg/gardener/shoot/hyperscaler"
 	"github.com/pkg/errors"
 	"k8s.io/utils/ptr"
@@ -13,22 +14,41 @@ const (
 	DefaultAzureCloudProfileName     = "az"
 	DefaultGCPCloudProfileName       = "gcp"
 	DefaultOpenStackCloudProfileName = "converged-cloud-kyma"
+	DefaultAlicloudCloudProfileName  = "alicloud"
 )
 
-func ExtendWithCloudProfile(runtime imv1.Runtime, shoot *gardener.Shoot) error {
-	cloudProfileName, err := getCloudProfileName(runtime)
+func NewCloudProfileExtender(cloudProfileConfig config.CloudProfileConfig) func(runtime imv1.Runtime, shoot *gardener.Shoot) error {
+	return func(runtime imv1.Runtime, shoot *gardener.Shoot) error {
+		cloudProfileName, err := GetCloudProfileName(runtime, cloudProfileConfig)
 
-	if err != nil {
-		return err
+		if err != nil {
+			return err
+		}
+
+		shoot.Spec.CloudProfileName = ptr.To(cloudProfileName)
+
+		return nil
 	}
+}
+
+// GetCloudProfileName returns the name of the Gardener CloudProfile used by the shoot of the given Runtime,
+// the names configured for the region or the provider take precedence over the defaults
+func GetCloudProfileName(runtime imv1.Runtime, cloudProfileConfig config.CloudProfileConfig) (string, error) {
+	providerType := runtime.Spec.Shoot.Provider.Type
 
-	shoot.Spec.CloudProfileName = ptr.To(cloudProfileName)
+	if name := cloudProfileConfig.NamesByRegion[providerType][runtime.Spec.Shoot.Region]; name != "" {
+		return name, nil
+	}
+
+	if name := cloudProfileConfig.NamesByProvider[providerType]; name != "" {
+		return name, nil
+	}
 
-	return nil
+	return getDefaultCloudProfileName(providerType)
 }
 
-func getCloudProfileName(runtime imv1.Runtime) (string, error) {
-	switch runtime.Spec.Shoot.Provider.Type {
+func getDefaultCloudProfileName(providerType string) (string, error) {
+	switch providerType {
 	case hyperscaler.TypeAWS:
 		return DefaultAWSCloudProfileName, nil
 	case hyperscaler.TypeGCP:
@@ -37,6 +57,8 @@ func getCloudProfileName(runtime imv1.Runtime) (string, error) {
 		return DefaultAzureCloudProfileName, nil
 	case hyperscaler.TypeOpenStack:
 		return DefaultOpenStackCloudProfileName, nil
+	case hyperscaler.TypeAlicloud:
+		return DefaultAlicloudCloudProfileName, nil
 	}
 
 	return "", errors.New("provider not supported")
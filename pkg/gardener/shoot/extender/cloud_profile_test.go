@@ -4,13 +4,14 @@ import (
 	"testing"
 
 	imv1 "github.com/kyma-project/infrastructure-manager/api/v1"
+	"github.com/kyma-project/infrastructure-manager/pkg/config"
 	"github.com/kyma-project/infrastructure-manager/pkg/gardener/shoot/hyperscaler"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"k8s.io/utils/ptr"
 )
 
-func TestExtendWithCloudProfile(t *testing.T) {
+func TestCloudProfileExtender(t *testing.T) {
 	for _, testCase := range []struct {
 		name            string
 		providerType    string
@@ -36,6 +37,11 @@ func TestExtendWithCloudProfile(t *testing.T) {
 			providerType:    hyperscaler.TypeOpenStack,
 			expectedProfile: ptr.To(DefaultOpenStackCloudProfileName),
 		},
+		{
+			name:            "Set cloud profile for alicloud",
+			providerType:    hyperscaler.TypeAlicloud,
+			expectedProfile: ptr.To(DefaultAlicloudCloudProfileName),
+		},
 	} {
 		t.Run(testCase.name, func(t *testing.T) {
 			// given
@@ -52,7 +58,7 @@ func TestExtendWithCloudProfile(t *testing.T) {
 			shoot := fixEmptyGardenerShoot("test", "dev")
 
 			// when
-			err := ExtendWithCloudProfile(runtime, &shoot)
+			err := NewCloudProfileExtender(config.CloudProfileConfig{})(runtime, &shoot)
 
 			// then
 			require.NoError(t, err)
@@ -60,7 +66,7 @@ func TestExtendWithCloudProfile(t *testing.T) {
 		})
 	}
 
-	t.Run("", func(t *testing.T) {
+	t.Run("Return error for unknown provider", func(t *testing.T) {
 		// given
 		runtime := imv1.Runtime{
 			Spec: imv1.RuntimeSpec{
@@ -75,9 +81,49 @@ func TestExtendWithCloudProfile(t *testing.T) {
 		shoot := fixEmptyGardenerShoot("test", "dev")
 
 		// when
-		err := ExtendWithCloudProfile(runtime, &shoot)
+		err := NewCloudProfileExtender(config.CloudProfileConfig{})(runtime, &shoot)
 
 		// then
 		require.Error(t, err)
 	})
+
+	t.Run("Set cloud profile names configured for provider and region", func(t *testing.T) {
+		// given
+		cloudProfileConfig := config.CloudProfileConfig{
+			NamesByProvider: map[string]string{
+				hyperscaler.TypeAWS: "aws-custom",
+			},
+			NamesByRegion: map[string]map[string]string{
+				hyperscaler.TypeAWS: {
+					"cn-north-1": "aws-china",
+				},
+			},
+		}
+		extender := NewCloudProfileExtender(cloudProfileConfig)
+
+		for region, expectedProfile := range map[string]string{
+			"eu-central-1": "aws-custom",
+			"cn-north-1":   "aws-china",
+		} {
+			runtime := imv1.Runtime{
+				Spec: imv1.RuntimeSpec{
+					Shoot: imv1.RuntimeShoot{
+						Name:   "myshoot",
+						Region: region,
+						Provider: imv1.Provider{
+							Type: hyperscaler.TypeAWS,
+						},
+					},
+				},
+			}
+			shoot := fixEmptyGardenerShoot("test", "dev")
+
+			// when
+			err := extender(runtime, &shoot)
+
+			// then
+			require.NoError(t, err)
+			assert.Equal(t, ptr.To(expectedProfile), shoot.Spec.CloudProfileName)
+		}
+	})
 }
@@ -3,15 +3,27 @@ package extender
 import (
 	gardener "github.com/gardener/gardener/pkg/apis/core/v1beta1"
 	imv1 "github.com/kyma-project/infrastructure-manager/api/v1"
+	"github.com/kyma-project/infrastructure-manager/pkg/config"
 	"github.com/kyma-project/infrastructure-manager/pkg/gardener/shoot/hyperscaler"
 	"k8s.io/utils/ptr"
 )
 
-// ExposureClassName is required only for OpenStack
-func ExtendWithExposureClassName(runtime imv1.Runtime, shoot *gardener.Shoot) error {
-	if runtime.Spec.Shoot.Provider.Type == hyperscaler.TypeOpenStack {
-		shoot.Spec.ExposureClassName = ptr.To("converged-cloud-internet")
-	}
+const defaultOpenStackExposureClassName = "converged-cloud-internet"
+
+// NewExposureClassNameExtender creates the extender setting the exposure class, which is required only for OpenStack.
+// The exposure class is taken from the Runtime, the region settings or the default one in this order
+func NewExposureClassNameExtender(cfg config.OpenStackConfig) func(runtime imv1.Runtime, shoot *gardener.Shoot) error {
+	return func(runtime imv1.Runtime, shoot *gardener.Shoot) error {
+		if runtime.Spec.Shoot.Provider.Type != hyperscaler.TypeOpenStack {
+			return nil
+		}
 
-	return nil
+		exposureClassName := getOpenStackSettings(runtime.Spec.Shoot, cfg).ExposureClassName
+		if exposureClassName == "" {
+			exposureClassName = defaultOpenStackExposureClassName
+		}
+		shoot.Spec.ExposureClassName = ptr.To(exposureClassName)
+
+		return nil
+	}
 }
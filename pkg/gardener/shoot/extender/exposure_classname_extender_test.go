@@ -4,26 +4,49 @@ import (
 	"testing"
 
 	imv1 "github.com/kyma-project/infrastructure-manager/api/v1"
+	"github.com/kyma-project/infrastructure-manager/pkg/config"
 	"github.com/kyma-project/infrastructure-manager/pkg/gardener/shoot/hyperscaler"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"k8s.io/utils/ptr"
 )
 
-func TestExtendWithExposureClassName(t *testing.T) {
+func TestExposureClassNameExtender(t *testing.T) {
+	openStackConfig := config.OpenStackConfig{
+		Regions: map[string]config.OpenStackRegionConfig{
+			"eu-de-2": {ExposureClassName: "converged-cloud-internet-eu-de-2"},
+		},
+	}
+
 	for _, testCase := range []struct {
-		name                         string
-		providerType                 string
-		expectedExposureClassNameSet bool
+		name                      string
+		providerType              string
+		region                    string
+		override                  *imv1.OpenStackProviderConfig
+		expectedExposureClassName *string
 	}{
 		{
-			name:                         "ExposureClassName not set for AWS",
-			providerType:                 hyperscaler.TypeAWS,
-			expectedExposureClassNameSet: false,
+			name:         "ExposureClassName not set for AWS",
+			providerType: hyperscaler.TypeAWS,
+		},
+		{
+			name:                      "Default ExposureClassName set for OpenStack",
+			providerType:              hyperscaler.TypeOpenStack,
+			region:                    "eu-de-1",
+			expectedExposureClassName: ptr.To("converged-cloud-internet"),
+		},
+		{
+			name:                      "ExposureClassName of the region set for OpenStack",
+			providerType:              hyperscaler.TypeOpenStack,
+			region:                    "eu-de-2",
+			expectedExposureClassName: ptr.To("converged-cloud-internet-eu-de-2"),
 		},
 		{
-			name:                         "ExposureClassName set for OpenStack",
-			providerType:                 hyperscaler.TypeOpenStack,
-			expectedExposureClassNameSet: true,
+			name:                      "ExposureClassName from Runtime set for OpenStack",
+			providerType:              hyperscaler.TypeOpenStack,
+			region:                    "eu-de-2",
+			override:                  &imv1.OpenStackProviderConfig{ExposureClassName: ptr.To("converged-cloud-internal")},
+			expectedExposureClassName: ptr.To("converged-cloud-internal"),
 		},
 	} {
 		t.Run(testCase.name, func(t *testing.T) {
@@ -31,9 +54,11 @@ func TestExtendWithExposureClassName(t *testing.T) {
 			runtime := imv1.Runtime{
 				Spec: imv1.RuntimeSpec{
 					Shoot: imv1.RuntimeShoot{
-						Name: "myshoot",
+						Name:   "myshoot",
+						Region: testCase.region,
 						Provider: imv1.Provider{
-							Type: testCase.providerType,
+							Type:      testCase.providerType,
+							OpenStack: testCase.override,
 						},
 					},
 				},
@@ -41,13 +66,11 @@ func TestExtendWithExposureClassName(t *testing.T) {
 			shoot := fixEmptyGardenerShoot("test", "dev")
 
 			// when
-			err := ExtendWithExposureClassName(runtime, &shoot)
-
-			exposureClassNameSet := (shoot.Spec.ExposureClassName != nil) && (*shoot.Spec.ExposureClassName == "converged-cloud-internet")
+			err := NewExposureClassNameExtender(openStackConfig)(runtime, &shoot)
 
 			// then
 			require.NoError(t, err)
-			assert.Equal(t, testCase.expectedExposureClassNameSet, exposureClassNameSet)
+			assert.Equal(t, testCase.expectedExposureClassName, shoot.Spec.ExposureClassName)
 		})
 	}
 }
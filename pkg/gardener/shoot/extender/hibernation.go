@@ -0,0 +1,14 @@
+package extender
+
+import (
+	gardener "github.com/gardener/gardener/pkg/apis/core/v1beta1"
+	imv1 "github.com/kyma-project/infrastructure-manager/api/v1"
+)
+
+func ExtendWithHibernation(runtime imv1.Runtime, shoot *gardener.Shoot) error {
+	if runtime.Spec.Shoot.Hibernation != nil {
+		shoot.Spec.Hibernation = runtime.Spec.Shoot.Hibernation.DeepCopy()
+	}
+
+	return nil
+}
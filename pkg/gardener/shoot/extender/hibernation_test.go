@@ -0,0 +1,55 @@
+package extender
+
+import (
+	"testing"
+
+	gardener "github.com/gardener/gardener/pkg/apis/core/v1beta1"
+	imv1 "github.com/kyma-project/infrastructure-manager/api/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/utils/ptr"
+)
+
+func TestHibernationExtender(t *testing.T) {
+	t.Run("Should copy hibernation schedules to shoot", func(t *testing.T) {
+		// given
+		shoot := fixEmptyGardenerShoot("test", "dev")
+		runtime := imv1.Runtime{
+			Spec: imv1.RuntimeSpec{
+				Shoot: imv1.RuntimeShoot{
+					Hibernation: &gardener.Hibernation{
+						Enabled: ptr.To(true),
+						Schedules: []gardener.HibernationSchedule{
+							{
+								Start:    ptr.To("00 20 * * 1,2,3,4,5"),
+								End:      ptr.To("00 07 * * 1,2,3,4,5"),
+								Location: ptr.To("Europe/Berlin"),
+							},
+						},
+					},
+				},
+			},
+		}
+
+		// when
+		err := ExtendWithHibernation(runtime, &shoot)
+
+		// then
+		require.NoError(t, err)
+		require.NotNil(t, shoot.Spec.Hibernation)
+		assert.Equal(t, runtime.Spec.Shoot.Hibernation, shoot.Spec.Hibernation)
+		assert.NotSame(t, runtime.Spec.Shoot.Hibernation, shoot.Spec.Hibernation)
+	})
+
+	t.Run("Should not set hibernation when not specified in Runtime", func(t *testing.T) {
+		// given
+		shoot := fixEmptyGardenerShoot("test", "dev")
+
+		// when
+		err := ExtendWithHibernation(imv1.Runtime{}, &shoot)
+
+		// then
+		require.NoError(t, err)
+		assert.Nil(t, shoot.Spec.Hibernation)
+	})
+}
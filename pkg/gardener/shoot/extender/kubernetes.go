@@ -12,14 +12,19 @@ import (
 // It sets the EnableStaticTokenKubeconfig field of the Shoot to false.
 func NewKubernetesExtender(defaultKubernetesVersion string) func(runtime imv1.Runtime, shoot *gardener.Shoot) error {
 	return func(runtime imv1.Runtime, shoot *gardener.Shoot) error {
-		kubernetesVersion := runtime.Spec.Shoot.Kubernetes.Version
-		if kubernetesVersion == nil || *kubernetesVersion == "" {
-			kubernetesVersion = &defaultKubernetesVersion
-		}
-
-		shoot.Spec.Kubernetes.Version = *kubernetesVersion
+		shoot.Spec.Kubernetes.Version = KubernetesVersionOrDefault(runtime, defaultKubernetesVersion)
 		shoot.Spec.Kubernetes.EnableStaticTokenKubeconfig = ptr.To(false)
 
 		return nil
 	}
 }
+
+// KubernetesVersionOrDefault returns the Kubernetes version specified in the Runtime, or `defaultKubernetesVersion` if it is not set.
+func KubernetesVersionOrDefault(runtime imv1.Runtime, defaultKubernetesVersion string) string {
+	kubernetesVersion := runtime.Spec.Shoot.Kubernetes.Version
+	if kubernetesVersion == nil || *kubernetesVersion == "" {
+		return defaultKubernetesVersion
+	}
+
+	return *kubernetesVersion
+}
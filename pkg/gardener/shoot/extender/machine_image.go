@@ -0,0 +1,43 @@
+package extender
+
+import (
+	"time"
+
+	gardener "github.com/gardener/gardener/pkg/apis/core/v1beta1"
+	"github.com/kyma-project/infrastructure-manager/pkg/gardener/cloudprofile"
+	"github.com/pkg/errors"
+	"k8s.io/utils/ptr"
+)
+
+// resolveMachineImageVersions sets the latest supported version from the cloud profile for the workers without machine image version,
+// and validates the versions requested explicitly. The cloud profile offering no version of the image is an error, as Gardener would reject any other version
+func resolveMachineImageVersions(workers []gardener.Worker, profile gardener.CloudProfile, now time.Time) error {
+	for i := 0; i < len(workers); i++ {
+		image := workers[i].Machine.Image
+
+		if image.Version == nil || *image.Version == "" {
+			latestVersion, err := cloudprofile.LatestMachineImageVersion(profile, image.Name, now)
+			if err != nil {
+				return errors.Wrapf(err, "failed to resolve machine image version for worker %s", workers[i].Name)
+			}
+
+			image.Version = ptr.To(latestVersion)
+			continue
+		}
+
+		if err := cloudprofile.ValidateMachineImageVersion(profile, image.Name, *image.Version, now); err != nil {
+			return errors.Wrapf(err, "machine image version unavailable for worker %s", workers[i].Name)
+		}
+	}
+
+	return nil
+}
+
+// pinnedVersionOrDefault returns the machine image version pinned in the configuration, or the default version
+func pinnedVersionOrDefault(pinnedVersion, defaultVersion string) string {
+	if pinnedVersion != "" {
+		return pinnedVersion
+	}
+
+	return defaultVersion
+}
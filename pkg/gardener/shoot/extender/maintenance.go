@@ -3,18 +3,55 @@ package extender
 import (
 	gardener "github.com/gardener/gardener/pkg/apis/core/v1beta1"
 	imv1 "github.com/kyma-project/infrastructure-manager/api/v1"
+	"github.com/kyma-project/infrastructure-manager/pkg/config"
 	"k8s.io/utils/ptr"
 )
 
-func NewMaintenanceExtender(enableKubernetesVersionAutoUpdate, enableMachineImageVersionAutoUpdate bool) func(runtime imv1.Runtime, shoot *gardener.Shoot) error { //nolint:revive
+func NewMaintenanceExtender(enableKubernetesVersionAutoUpdate, enableMachineImageVersionAutoUpdate bool, defaultWindows config.MaintenanceWindowConfig) func(runtime imv1.Runtime, shoot *gardener.Shoot) error { //nolint:revive
 	return func(runtime imv1.Runtime, shoot *gardener.Shoot) error { //nolint:revive
+		maintenance := runtime.Spec.Shoot.Maintenance
+		if maintenance == nil {
+			maintenance = &imv1.Maintenance{}
+		}
+
+		kubernetesVersionAutoUpdate := enableKubernetesVersionAutoUpdate
+		machineImageVersionAutoUpdate := enableMachineImageVersionAutoUpdate
+
+		if maintenance.AutoUpdate != nil {
+			if maintenance.AutoUpdate.KubernetesVersion != nil {
+				kubernetesVersionAutoUpdate = *maintenance.AutoUpdate.KubernetesVersion
+			}
+			if maintenance.AutoUpdate.MachineImageVersion != nil {
+				machineImageVersionAutoUpdate = *maintenance.AutoUpdate.MachineImageVersion
+			}
+		}
+
 		shoot.Spec.Maintenance = &gardener.Maintenance{
 			AutoUpdate: &gardener.MaintenanceAutoUpdate{
-				KubernetesVersion:   enableKubernetesVersionAutoUpdate,
-				MachineImageVersion: ptr.To(enableMachineImageVersionAutoUpdate),
+				KubernetesVersion:   kubernetesVersionAutoUpdate,
+				MachineImageVersion: ptr.To(machineImageVersionAutoUpdate),
 			},
+			TimeWindow: getTimeWindow(maintenance.TimeWindow, runtime.Spec.Shoot.Region, defaultWindows),
 		}
 
 		return nil
 	}
 }
+
+// the time window from the Runtime takes precedence over the default for the region,
+// nil leaves the choice of the time window to Gardener
+func getTimeWindow(timeWindow *gardener.MaintenanceTimeWindow, region string, defaultWindows config.MaintenanceWindowConfig) *gardener.MaintenanceTimeWindow {
+	if timeWindow != nil {
+		return timeWindow.DeepCopy()
+	}
+
+	defaultWindow, found := defaultWindows.WindowsByRegion[region]
+	if !found {
+		return nil
+	}
+
+	return &gardener.MaintenanceTimeWindow{
+		Begin: defaultWindow.Begin,
+		End:   defaultWindow.End,
+	}
+}
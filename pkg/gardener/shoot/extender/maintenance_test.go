@@ -5,7 +5,10 @@ import (
 
 	gardener "github.com/gardener/gardener/pkg/apis/core/v1beta1"
 	imv1 "github.com/kyma-project/infrastructure-manager/api/v1"
+	"github.com/kyma-project/infrastructure-manager/pkg/config"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/utils/ptr"
 )
 
 func TestMaintenanceExtender(t *testing.T) {
@@ -40,7 +43,7 @@ func TestMaintenanceExtender(t *testing.T) {
 			}
 
 			// when
-			extender := NewMaintenanceExtender(testCase.enableKubernetesVersionAutoUpdate, testCase.enableMachineImageVersionAutoUpdate)
+			extender := NewMaintenanceExtender(testCase.enableKubernetesVersionAutoUpdate, testCase.enableMachineImageVersionAutoUpdate, config.MaintenanceWindowConfig{})
 			err := extender(runtimeShoot, &shoot)
 
 			// then
@@ -50,3 +53,85 @@ func TestMaintenanceExtender(t *testing.T) {
 		})
 	}
 }
+
+func TestMaintenanceExtenderTimeWindow(t *testing.T) {
+	defaultWindows := config.MaintenanceWindowConfig{
+		WindowsByRegion: map[string]config.MaintenanceTimeWindow{
+			"eu-central-1": {Begin: "030000+0000", End: "040000+0000"},
+		},
+	}
+
+	for _, testCase := range []struct {
+		name               string
+		region             string
+		maintenance        *imv1.Maintenance
+		expectedTimeWindow *gardener.MaintenanceTimeWindow
+	}{
+		{
+			name:               "Use default time window for region",
+			region:             "eu-central-1",
+			expectedTimeWindow: &gardener.MaintenanceTimeWindow{Begin: "030000+0000", End: "040000+0000"},
+		},
+		{
+			name:   "Use time window from Runtime",
+			region: "eu-central-1",
+			maintenance: &imv1.Maintenance{
+				TimeWindow: &gardener.MaintenanceTimeWindow{Begin: "220000+0100", End: "230000+0100"},
+			},
+			expectedTimeWindow: &gardener.MaintenanceTimeWindow{Begin: "220000+0100", End: "230000+0100"},
+		},
+		{
+			name:               "Leave time window to Gardener for region without default",
+			region:             "westeurope",
+			expectedTimeWindow: nil,
+		},
+	} {
+		t.Run(testCase.name, func(t *testing.T) {
+			// given
+			shoot := fixEmptyGardenerShoot("test", "dev")
+			runtime := imv1.Runtime{
+				Spec: imv1.RuntimeSpec{
+					Shoot: imv1.RuntimeShoot{
+						Name:        "test",
+						Region:      testCase.region,
+						Maintenance: testCase.maintenance,
+					},
+				},
+			}
+
+			// when
+			extender := NewMaintenanceExtender(true, true, defaultWindows)
+			err := extender(runtime, &shoot)
+
+			// then
+			require.NoError(t, err)
+			assert.Equal(t, testCase.expectedTimeWindow, shoot.Spec.Maintenance.TimeWindow)
+		})
+	}
+}
+
+func TestMaintenanceExtenderAutoUpdateOverrides(t *testing.T) {
+	// given
+	shoot := fixEmptyGardenerShoot("test", "dev")
+	runtime := imv1.Runtime{
+		Spec: imv1.RuntimeSpec{
+			Shoot: imv1.RuntimeShoot{
+				Name: "test",
+				Maintenance: &imv1.Maintenance{
+					AutoUpdate: &imv1.MaintenanceAutoUpdate{
+						KubernetesVersion: ptr.To(false),
+					},
+				},
+			},
+		},
+	}
+
+	// when
+	extender := NewMaintenanceExtender(true, true, config.MaintenanceWindowConfig{})
+	err := extender(runtime, &shoot)
+
+	// then
+	require.NoError(t, err)
+	assert.False(t, shoot.Spec.Maintenance.AutoUpdate.KubernetesVersion)
+	assert.True(t, *shoot.Spec.Maintenance.AutoUpdate.MachineImageVersion)
+}
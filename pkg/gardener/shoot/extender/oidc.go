@@ -23,14 +23,7 @@ func NewOidcExtender(oidcProvider config.OidcProvider) func(runtime imv1.Runtime
 
 		oidcConfig := runtime.Spec.Shoot.Kubernetes.KubeAPIServer.OidcConfig
 		if shouldDefaultOidcConfig(oidcConfig) {
-			oidcConfig = gardener.OIDCConfig{
-				ClientID:       &oidcProvider.ClientID,
-				GroupsClaim:    &oidcProvider.GroupsClaim,
-				IssuerURL:      &oidcProvider.IssuerURL,
-				SigningAlgs:    oidcProvider.SigningAlgs,
-				UsernameClaim:  &oidcProvider.UsernameClaim,
-				UsernamePrefix: &oidcProvider.UsernamePrefix,
-			}
+			oidcConfig = toOidcConfig(oidcProvider)
 		}
 		setKubeAPIServerOIDCConfig(shoot, oidcConfig)
 
@@ -38,6 +31,26 @@ func NewOidcExtender(oidcProvider config.OidcProvider) func(runtime imv1.Runtime
 	}
 }
 
+// DefaultAdditionalOidcIfNotPresent sets the shared IAS tenant as the only additional OIDC config if the Runtime does not specify any
+func DefaultAdditionalOidcIfNotPresent(runtime *imv1.Runtime, defaultSharedIASTenant config.OidcProvider) {
+	if runtime.Spec.Shoot.Kubernetes.KubeAPIServer.AdditionalOidcConfig == nil {
+		runtime.Spec.Shoot.Kubernetes.KubeAPIServer.AdditionalOidcConfig = &[]gardener.OIDCConfig{
+			toOidcConfig(defaultSharedIASTenant),
+		}
+	}
+}
+
+func toOidcConfig(oidcProvider config.OidcProvider) gardener.OIDCConfig {
+	return gardener.OIDCConfig{
+		ClientID:       &oidcProvider.ClientID,
+		GroupsClaim:    &oidcProvider.GroupsClaim,
+		IssuerURL:      &oidcProvider.IssuerURL,
+		SigningAlgs:    oidcProvider.SigningAlgs,
+		UsernameClaim:  &oidcProvider.UsernameClaim,
+		UsernamePrefix: &oidcProvider.UsernamePrefix,
+	}
+}
+
 func CanEnableExtension(runtime imv1.Runtime) bool {
 	return runtime.Labels["operator.kyma-project.io/created-by-migrator"] != "true"
 }
@@ -0,0 +1,38 @@
+package extender
+
+import (
+	imv1 "github.com/kyma-project/infrastructure-manager/api/v1"
+	"github.com/kyma-project/infrastructure-manager/pkg/config"
+	"github.com/kyma-project/infrastructure-manager/pkg/gardener/shoot/hyperscaler/openstack"
+)
+
+// getOpenStackSettings returns the OpenStack settings of the Runtime region, overridden by the ones set in the Runtime
+func getOpenStackSettings(runtimeShoot imv1.RuntimeShoot, cfg config.OpenStackConfig) config.OpenStackRegionConfig {
+	settings := cfg.ForRegion(runtimeShoot.Region)
+
+	override := runtimeShoot.Provider.OpenStack
+	if override == nil {
+		return settings
+	}
+
+	if override.FloatingPoolName != nil {
+		settings.FloatingPoolName = *override.FloatingPoolName
+	}
+	if override.LoadBalancerProvider != nil {
+		settings.LoadBalancerProvider = *override.LoadBalancerProvider
+	}
+	if override.ExposureClassName != nil {
+		settings.ExposureClassName = *override.ExposureClassName
+	}
+
+	return settings
+}
+
+func getOpenStackOptions(runtimeShoot imv1.RuntimeShoot, cfg config.OpenStackConfig) openstack.Options {
+	settings := getOpenStackSettings(runtimeShoot, cfg)
+
+	return openstack.Options{
+		FloatingPoolName:     settings.FloatingPoolName,
+		LoadBalancerProvider: settings.LoadBalancerProvider,
+	}
+}
@@ -1,11 +1,16 @@
 package extender
 
 import (
+	"encoding/json"
+	"maps"
 	"slices"
+	"time"
 
 	gardener "github.com/gardener/gardener/pkg/apis/core/v1beta1"
 	imv1 "github.com/kyma-project/infrastructure-manager/api/v1"
+	"github.com/kyma-project/infrastructure-manager/pkg/config"
 	"github.com/kyma-project/infrastructure-manager/pkg/gardener/shoot/hyperscaler"
+	"github.com/kyma-project/infrastructure-manager/pkg/gardener/shoot/hyperscaler/alicloud"
 	"github.com/kyma-project/infrastructure-manager/pkg/gardener/shoot/hyperscaler/aws"
 	"github.com/kyma-project/infrastructure-manager/pkg/gardener/shoot/hyperscaler/azure"
 	"github.com/kyma-project/infrastructure-manager/pkg/gardener/shoot/hyperscaler/gcp"
@@ -14,19 +19,30 @@ import (
 	"k8s.io/apimachinery/pkg/runtime"
 )
 
-func NewProviderExtender(enableIMDSv2 bool, defaultMachineImageName, defaultMachineImageVersion string) func(runtime imv1.Runtime, shoot *gardener.Shoot) error {
+// NewProviderExtender creates the provider extender, the machine image versions are resolved from the cloud profile if it is given,
+// otherwise the pinned or the default machine image version is used
+func NewProviderExtender(enableIMDSv2 bool, openStackConfig config.OpenStackConfig, defaultMachineImageName, defaultMachineImageVersion, pinnedMachineImageVersion string, cloudProfile *gardener.CloudProfile) func(runtime imv1.Runtime, shoot *gardener.Shoot) error {
 	return func(runtime imv1.Runtime, shoot *gardener.Shoot) error {
 		provider := &shoot.Spec.Provider
 		provider.Type = runtime.Spec.Shoot.Provider.Type
-		provider.Workers = runtime.Spec.Shoot.Provider.Workers
+		// the workers are copied, the defaults set by the extenders must not be written into the Runtime
+		provider.Workers = copyWorkers(runtime.Spec.Shoot.Provider.Workers)
 
 		var err error
-		provider.InfrastructureConfig, provider.ControlPlaneConfig, err = getConfig(runtime.Spec.Shoot)
+		provider.InfrastructureConfig, provider.ControlPlaneConfig, err = getConfig(runtime.Spec.Shoot, openStackConfig)
 		if err != nil {
 			return err
 		}
 
-		setDefaultMachineImage(provider, defaultMachineImageName, defaultMachineImageVersion)
+		if cloudProfile == nil {
+			SetDefaultMachineImage(provider.Workers, defaultMachineImageName, pinnedVersionOrDefault(pinnedMachineImageVersion, defaultMachineImageVersion))
+		} else {
+			SetDefaultMachineImage(provider.Workers, defaultMachineImageName, pinnedMachineImageVersion)
+			if err = resolveMachineImageVersions(provider.Workers, *cloudProfile, time.Now()); err != nil {
+				return err
+			}
+		}
+
 		err = setWorkerConfig(provider, provider.Type, enableIMDSv2)
 		setWorkerSettings(provider)
 
@@ -34,10 +50,23 @@ func NewProviderExtender(enableIMDSv2 bool, defaultMachineImageName, defaultMach
 	}
 }
 
+func copyWorkers(workers []gardener.Worker) []gardener.Worker {
+	if workers == nil {
+		return nil
+	}
+
+	copied := make([]gardener.Worker, len(workers))
+	for i := range workers {
+		workers[i].DeepCopyInto(&copied[i])
+	}
+
+	return copied
+}
+
 type InfrastructureProviderFunc func(workersCidr string, zones []string) ([]byte, error)
 type ControlPlaneProviderFunc func(zones []string) ([]byte, error)
 
-func getConfig(runtimeShoot imv1.RuntimeShoot) (infrastructureConfig *runtime.RawExtension, controlPlaneConfig *runtime.RawExtension, err error) {
+func getConfig(runtimeShoot imv1.RuntimeShoot, openStackConfig config.OpenStackConfig) (infrastructureConfig *runtime.RawExtension, controlPlaneConfig *runtime.RawExtension, err error) {
 	getConfigForProvider := func(runtimeShoot imv1.RuntimeShoot, infrastructureConfigFunc InfrastructureProviderFunc, controlPlaneConfigFunc ControlPlaneProviderFunc) (*runtime.RawExtension, *runtime.RawExtension, error) {
 		zones := getZones(runtimeShoot.Provider.Workers)
 
@@ -57,33 +86,125 @@ func getConfig(runtimeShoot imv1.RuntimeShoot) (infrastructureConfig *runtime.Ra
 	switch runtimeShoot.Provider.Type {
 	case hyperscaler.TypeAWS:
 		{
-			return getConfigForProvider(runtimeShoot, aws.GetInfrastructureConfig, aws.GetControlPlaneConfig)
+			return getConfigForProvider(runtimeShoot, getAWSInfrastructureConfigFunc(runtimeShoot.Provider, runtimeShoot.Networking.ZoneBits), aws.GetControlPlaneConfig)
 		}
 	case hyperscaler.TypeAzure:
 		{
 			// Azure shoots are all zoned, put probably it not be validated here.
-			return getConfigForProvider(runtimeShoot, azure.GetInfrastructureConfig, azure.GetControlPlaneConfig)
+			return getConfigForProvider(runtimeShoot, getAzureInfrastructureConfigFunc(runtimeShoot.Provider, runtimeShoot.Networking.ZoneBits), azure.GetControlPlaneConfig)
 		}
 	case hyperscaler.TypeGCP:
 		{
-			return getConfigForProvider(runtimeShoot, gcp.GetInfrastructureConfig, gcp.GetControlPlaneConfig)
+			return getConfigForProvider(runtimeShoot, getGCPInfrastructureConfigFunc(runtimeShoot.Provider), getGCPControlPlaneConfigFunc(runtimeShoot.Provider))
 		}
 	case hyperscaler.TypeOpenStack:
 		{
-			return getConfigForProvider(runtimeShoot, openstack.GetInfrastructureConfig, openstack.GetControlPlaneConfig)
+			options := getOpenStackOptions(runtimeShoot, openStackConfig)
+			return getConfigForProvider(runtimeShoot,
+				func(workersCidr string, zones []string) ([]byte, error) {
+					return openstack.GetInfrastructureConfigWithOptions(workersCidr, zones, options)
+				},
+				func(zones []string) ([]byte, error) {
+					return openstack.GetControlPlaneConfigWithOptions(zones, options)
+				})
+		}
+	case hyperscaler.TypeAlicloud:
+		{
+			zoneBits := runtimeShoot.Networking.ZoneBits
+			return getConfigForProvider(runtimeShoot,
+				func(workersCidr string, zones []string) ([]byte, error) {
+					return alicloud.GetInfrastructureConfigWithZoneBits(workersCidr, zones, zoneBits)
+				},
+				alicloud.GetControlPlaneConfig)
 		}
 	default:
 		return nil, nil, errors.New("provider not supported")
 	}
 }
 
-func getAWSWorkerConfig() (*runtime.RawExtension, error) {
-	workerConfigBytes, err := aws.GetWorkerConfig()
-	if err != nil {
-		return nil, err
+// the existing VPC and the zone layout are used if they are specified in the Runtime
+func getAWSInfrastructureConfigFunc(provider imv1.Provider, zoneBits *int) InfrastructureProviderFunc {
+	options := aws.Options{ZoneBits: zoneBits}
+	if provider.AWS != nil && provider.AWS.VPCID != nil {
+		options.VPCID = *provider.AWS.VPCID
 	}
 
-	return &runtime.RawExtension{Raw: workerConfigBytes}, nil
+	return func(workersCidr string, zones []string) ([]byte, error) {
+		return aws.GetInfrastructureConfigWithOptions(workersCidr, zones, options)
+	}
+}
+
+// the existing VNet, the NAT gateway settings and the zone layout are taken from the Runtime
+func getAzureInfrastructureConfigFunc(provider imv1.Provider, zoneBits *int) InfrastructureProviderFunc {
+	options := azure.DefaultInfrastructureOptions()
+	options.ZoneBits = zoneBits
+	if provider.Azure == nil {
+		return func(workersCidr string, zones []string) ([]byte, error) {
+			return azure.GetInfrastructureConfigWithOptions(workersCidr, zones, options)
+		}
+	}
+
+	if vnet := provider.Azure.VNet; vnet != nil {
+		options.ExistingVNet = &azure.ExistingVNet{
+			Name:          vnet.Name,
+			ResourceGroup: vnet.ResourceGroup,
+		}
+	}
+
+	if natGateway := provider.Azure.NatGateway; natGateway != nil {
+		if natGateway.Enabled != nil {
+			options.NatGateway.Enabled = *natGateway.Enabled
+		}
+		if natGateway.IdleConnectionTimeoutMinutes != nil {
+			options.NatGateway.IdleConnectionTimeoutMinutes = *natGateway.IdleConnectionTimeoutMinutes
+		}
+		for _, ipAddress := range natGateway.IPAddresses {
+			options.NatGateway.IPAddresses = append(options.NatGateway.IPAddresses, azure.PublicIPReference{
+				Name:          ipAddress.Name,
+				ResourceGroup: ipAddress.ResourceGroup,
+				Zone:          ipAddress.Zone,
+			})
+		}
+	}
+
+	return func(workersCidr string, zones []string) ([]byte, error) {
+		return azure.GetInfrastructureConfigWithOptions(workersCidr, zones, options)
+	}
+}
+
+// the internal subnet and the Cloud NAT settings are taken from the Runtime
+func getGCPInfrastructureConfigFunc(provider imv1.Provider) InfrastructureProviderFunc {
+	if provider.GCP == nil {
+		return gcp.GetInfrastructureConfig
+	}
+
+	var options gcp.InfrastructureOptions
+	if provider.GCP.Internal != nil {
+		options.InternalCIDR = *provider.GCP.Internal
+	}
+
+	if cloudNAT := provider.GCP.CloudNAT; cloudNAT != nil {
+		options.CloudNAT = &gcp.CloudNATOptions{
+			MinPortsPerVM: cloudNAT.MinPortsPerVM,
+			NatIPNames:    cloudNAT.NatIPNames,
+		}
+	}
+
+	return func(workersCidr string, zones []string) ([]byte, error) {
+		return gcp.GetInfrastructureConfigWithOptions(workersCidr, zones, options)
+	}
+}
+
+// the control plane is placed in the first zone unless the Runtime chooses its zone
+func getGCPControlPlaneConfigFunc(provider imv1.Provider) ControlPlaneProviderFunc {
+	if provider.GCP == nil || provider.GCP.ControlPlaneZone == nil {
+		return gcp.GetControlPlaneConfig
+	}
+
+	controlPlaneZone := *provider.GCP.ControlPlaneZone
+	return func(zones []string) ([]byte, error) {
+		return gcp.GetControlPlaneConfigForZone(controlPlaneZone, zones)
+	}
 }
 
 func getZones(workers []gardener.Worker) []string {
@@ -100,23 +221,75 @@ func getZones(workers []gardener.Worker) []string {
 	return zones
 }
 
+// setWorkerConfig merges the provider config of every worker set in the Runtime with the default one,
+// the values from the Runtime take precedence
 func setWorkerConfig(provider *gardener.Provider, providerType string, enableIMDSv2 bool) error {
-	if providerType != hyperscaler.TypeAWS || !enableIMDSv2 {
-		return nil
+	defaultWorkerConfig, err := getDefaultWorkerConfig(providerType, enableIMDSv2)
+	if err != nil {
+		return err
 	}
 
 	for i := 0; i < len(provider.Workers); i++ {
-		var err error
-		provider.Workers[i].ProviderConfig, err = getAWSWorkerConfig()
+		worker := &provider.Workers[i]
+
+		if worker.ProviderConfig == nil || len(worker.ProviderConfig.Raw) == 0 {
+			if defaultWorkerConfig != nil {
+				worker.ProviderConfig = &runtime.RawExtension{Raw: defaultWorkerConfig}
+			}
+			continue
+		}
 
+		workerConfig, err := mergeWorkerConfig(defaultWorkerConfig, worker.ProviderConfig.Raw)
 		if err != nil {
-			return err
+			return errors.Wrapf(err, "failed to merge provider config of worker %s", worker.Name)
 		}
+		worker.ProviderConfig = &runtime.RawExtension{Raw: workerConfig}
 	}
 
 	return nil
 }
 
+func getDefaultWorkerConfig(providerType string, enableIMDSv2 bool) ([]byte, error) {
+	if providerType != hyperscaler.TypeAWS || !enableIMDSv2 {
+		return nil, nil
+	}
+
+	return aws.GetWorkerConfig()
+}
+
+func mergeWorkerConfig(defaultWorkerConfig, workerConfig []byte) ([]byte, error) {
+	if defaultWorkerConfig == nil {
+		return workerConfig, nil
+	}
+
+	var defaults, overrides map[string]any
+	if err := json.Unmarshal(defaultWorkerConfig, &defaults); err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(workerConfig, &overrides); err != nil {
+		return nil, err
+	}
+
+	return json.Marshal(mergeJSONObjects(defaults, overrides))
+}
+
+// mergeJSONObjects merges the nested objects recursively, all the other values (including lists) are replaced
+func mergeJSONObjects(defaults, overrides map[string]any) map[string]any {
+	merged := maps.Clone(defaults)
+	for key, value := range overrides {
+		defaultObject, isDefaultObject := merged[key].(map[string]any)
+		object, isObject := value.(map[string]any)
+		if isDefaultObject && isObject {
+			merged[key] = mergeJSONObjects(defaultObject, object)
+			continue
+		}
+
+		merged[key] = value
+	}
+
+	return merged
+}
+
 func setWorkerSettings(provider *gardener.Provider) {
 	provider.WorkersSettings = &gardener.WorkersSettings{
 		SSHAccess: &gardener.SSHAccess{
@@ -125,20 +298,20 @@ func setWorkerSettings(provider *gardener.Provider) {
 	}
 }
 
-func setDefaultMachineImage(provider *gardener.Provider, defaultMachineImageName, defaultMachineImageVersion string) {
-	for i := 0; i < len(provider.Workers); i++ {
-		worker := &provider.Workers[i]
+// SetDefaultMachineImage fills in the machine image name and version of the workers which do not specify them,
+// the version is left empty if no default version is given
+func SetDefaultMachineImage(workers []gardener.Worker, defaultMachineImageName, defaultMachineImageVersion string) {
+	for i := 0; i < len(workers); i++ {
+		worker := &workers[i]
 
 		if worker.Machine.Image == nil {
 			worker.Machine.Image = &gardener.ShootMachineImage{
-				Name:    defaultMachineImageName,
-				Version: &defaultMachineImageVersion,
+				Name: defaultMachineImageName,
 			}
-
-			continue
 		}
+
 		machineImageVersion := worker.Machine.Image.Version
-		if machineImageVersion == nil || *machineImageVersion == "" {
+		if (machineImageVersion == nil || *machineImageVersion == "") && defaultMachineImageVersion != "" {
 			machineImageVersion = &defaultMachineImageVersion
 		}
 
@@ -3,13 +3,20 @@ package extender
 import (
 	"encoding/json"
 	"testing"
+	"time"
 
 	"github.com/gardener/gardener-extension-provider-aws/pkg/apis/aws/v1alpha1"
+	openstackv1alpha1 "github.com/gardener/gardener-extension-provider-openstack/pkg/apis/openstack/v1alpha1"
 	gardener "github.com/gardener/gardener/pkg/apis/core/v1beta1"
 	imv1 "github.com/kyma-project/infrastructure-manager/api/v1"
+	"github.com/kyma-project/infrastructure-manager/pkg/config"
+	"github.com/kyma-project/infrastructure-manager/pkg/gardener/cloudprofile"
 	"github.com/kyma-project/infrastructure-manager/pkg/gardener/shoot/hyperscaler"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/utils/ptr"
 )
 
 func TestProviderExtender(t *testing.T) {
@@ -27,6 +34,9 @@ func TestProviderExtender(t *testing.T) {
 				Spec: imv1.RuntimeSpec{
 					Shoot: imv1.RuntimeShoot{
 						Provider: fixAWSProvider("1312.2.0"),
+						Networking: imv1.Networking{
+							Nodes: "10.250.0.0/16",
+						},
 					},
 				},
 			},
@@ -40,6 +50,9 @@ func TestProviderExtender(t *testing.T) {
 				Spec: imv1.RuntimeSpec{
 					Shoot: imv1.RuntimeShoot{
 						Provider: fixAWSProvider(""),
+						Networking: imv1.Networking{
+							Nodes: "10.250.0.0/16",
+						},
 					},
 				},
 			},
@@ -53,6 +66,9 @@ func TestProviderExtender(t *testing.T) {
 				Spec: imv1.RuntimeSpec{
 					Shoot: imv1.RuntimeShoot{
 						Provider: fixAWSProviderWithMultipleWorkers(),
+						Networking: imv1.Networking{
+							Nodes: "10.250.0.0/16",
+						},
 					},
 				},
 			},
@@ -65,13 +81,15 @@ func TestProviderExtender(t *testing.T) {
 		t.Run(tname, func(t *testing.T) {
 			// given
 			shoot := fixEmptyGardenerShoot("cluster", "kcp-system")
+			runtimeWorkers := testCase.Runtime.DeepCopy().Spec.Shoot.Provider.Workers
 
 			// when
-			extender := NewProviderExtender(testCase.EnableIMDSv2, testCase.DefaultMachineImageName, testCase.DefaultMachineImageVersion)
+			extender := NewProviderExtender(testCase.EnableIMDSv2, config.OpenStackConfig{}, testCase.DefaultMachineImageName, testCase.DefaultMachineImageVersion, "", nil)
 			err := extender(testCase.Runtime, &shoot)
 
 			// then
 			require.NoError(t, err)
+			assert.Equal(t, runtimeWorkers, testCase.Runtime.Spec.Shoot.Provider.Workers)
 
 			assertProvider(t, testCase.Runtime.Spec.Shoot, shoot, testCase.EnableIMDSv2, testCase.ExpectedMachineImageName, testCase.ExpectedMachineImageVersion)
 			assertProviderSpecificConfig(t, shoot, testCase.ExpectedZonesCount)
@@ -92,7 +110,7 @@ func TestProviderExtender(t *testing.T) {
 		}
 
 		// when
-		extender := NewProviderExtender(false, "", "")
+		extender := NewProviderExtender(false, config.OpenStackConfig{}, "", "", "", nil)
 		err := extender(runtime, &shoot)
 
 		// then
@@ -100,6 +118,224 @@ func TestProviderExtender(t *testing.T) {
 	})
 }
 
+func TestProviderExtenderWithCloudProfile(t *testing.T) {
+	cloudProfile := gardener.CloudProfile{
+		ObjectMeta: metav1.ObjectMeta{Name: "aws"},
+		Spec: gardener.CloudProfileSpec{
+			MachineImages: []gardener.MachineImage{
+				{
+					Name: "gardenlinux",
+					Versions: []gardener.MachineImageVersion{
+						{ExpirableVersion: gardener.ExpirableVersion{Version: "1592.2.0", Classification: ptr.To(gardener.ClassificationPreview)}},
+						{ExpirableVersion: gardener.ExpirableVersion{Version: "1592.1.0", Classification: ptr.To(gardener.ClassificationSupported)}},
+						{ExpirableVersion: gardener.ExpirableVersion{Version: "1443.10.0", Classification: ptr.To(gardener.ClassificationSupported)}},
+						{ExpirableVersion: gardener.ExpirableVersion{Version: "1312.3.0", ExpirationDate: &metav1.Time{Time: time.Now().Add(-time.Hour)}}},
+					},
+				},
+			},
+		},
+	}
+
+	for tname, testCase := range map[string]struct {
+		DefaultMachineImageName      string
+		RequestedMachineImageVersion string
+		PinnedMachineImageVersion    string
+		ExpectedMachineImageVersion  string
+		ExpectedErr                  error
+	}{
+		"Resolve latest supported machine image version": {
+			ExpectedMachineImageVersion: "1592.1.0",
+		},
+		"Use pinned machine image version": {
+			PinnedMachineImageVersion:   "1443.10.0",
+			ExpectedMachineImageVersion: "1443.10.0",
+		},
+		"Keep requested machine image version": {
+			RequestedMachineImageVersion: "1443.10.0",
+			PinnedMachineImageVersion:    "1592.1.0",
+			ExpectedMachineImageVersion:  "1443.10.0",
+		},
+		"Return error for machine image not offered by cloud profile": {
+			DefaultMachineImageName: "ubuntu",
+			ExpectedErr:             cloudprofile.ErrMachineImageNotFound,
+		},
+		"Return error for expired machine image version": {
+			RequestedMachineImageVersion: "1312.3.0",
+			ExpectedErr:                  cloudprofile.ErrMachineImageVersionExpired,
+		},
+		"Return error for machine image version not offered by cloud profile": {
+			PinnedMachineImageVersion: "1000.0.0",
+			ExpectedErr:               cloudprofile.ErrMachineImageVersionNotFound,
+		},
+	} {
+		t.Run(tname, func(t *testing.T) {
+			// given
+			shoot := fixEmptyGardenerShoot("cluster", "kcp-system")
+			runtime := imv1.Runtime{
+				Spec: imv1.RuntimeSpec{
+					Shoot: imv1.RuntimeShoot{
+						Provider: fixAWSProvider(testCase.RequestedMachineImageVersion),
+						Networking: imv1.Networking{
+							Nodes: "10.250.0.0/16",
+						},
+					},
+				},
+			}
+
+			defaultMachineImageName := "gardenlinux"
+			if testCase.DefaultMachineImageName != "" {
+				defaultMachineImageName = testCase.DefaultMachineImageName
+			}
+
+			// when
+			extender := NewProviderExtender(false, config.OpenStackConfig{}, defaultMachineImageName, "1312.3.0", testCase.PinnedMachineImageVersion, &cloudProfile)
+			err := extender(runtime, &shoot)
+
+			// then
+			if testCase.ExpectedErr != nil {
+				require.ErrorIs(t, err, testCase.ExpectedErr)
+				return
+			}
+
+			require.NoError(t, err)
+			assertProvider(t, runtime.Spec.Shoot, shoot, false, defaultMachineImageName, testCase.ExpectedMachineImageVersion)
+		})
+	}
+}
+
+func TestProviderExtenderWorkerConfig(t *testing.T) {
+	for tname, testCase := range map[string]struct {
+		EnableIMDSv2         bool
+		WorkerConfig         string
+		ExpectedWorkerConfig v1alpha1.WorkerConfig
+	}{
+		"Merge worker config from Runtime with default one": {
+			EnableIMDSv2: true,
+			WorkerConfig: `{"apiVersion":"aws.provider.extensions.gardener.cloud/v1alpha1","kind":"WorkerConfig","volume":{"iops":3000,"throughput":200},"instanceMetadataOptions":{"httpPutResponseHopLimit":3}}`,
+			ExpectedWorkerConfig: v1alpha1.WorkerConfig{
+				TypeMeta: metav1.TypeMeta{APIVersion: "aws.provider.extensions.gardener.cloud/v1alpha1", Kind: "WorkerConfig"},
+				Volume:   &v1alpha1.Volume{IOPS: ptr.To(int64(3000)), Throughput: ptr.To(int64(200))},
+				InstanceMetadataOptions: &v1alpha1.InstanceMetadataOptions{
+					HTTPTokens:              ptr.To(v1alpha1.HTTPTokensRequired),
+					HTTPPutResponseHopLimit: ptr.To(int64(3)),
+				},
+			},
+		},
+		"Keep worker config from Runtime without default one": {
+			EnableIMDSv2: false,
+			WorkerConfig: `{"apiVersion":"aws.provider.extensions.gardener.cloud/v1alpha1","kind":"WorkerConfig","volume":{"iops":3000}}`,
+			ExpectedWorkerConfig: v1alpha1.WorkerConfig{
+				TypeMeta: metav1.TypeMeta{APIVersion: "aws.provider.extensions.gardener.cloud/v1alpha1", Kind: "WorkerConfig"},
+				Volume:   &v1alpha1.Volume{IOPS: ptr.To(int64(3000))},
+			},
+		},
+	} {
+		t.Run(tname, func(t *testing.T) {
+			// given
+			shoot := fixEmptyGardenerShoot("cluster", "kcp-system")
+			provider := fixAWSProvider("1312.3.0")
+			provider.Workers[0].ProviderConfig = &runtime.RawExtension{Raw: []byte(testCase.WorkerConfig)}
+			rt := imv1.Runtime{
+				Spec: imv1.RuntimeSpec{
+					Shoot: imv1.RuntimeShoot{
+						Provider: provider,
+						Networking: imv1.Networking{
+							Nodes: "10.250.0.0/16",
+						},
+					},
+				},
+			}
+
+			// when
+			extender := NewProviderExtender(testCase.EnableIMDSv2, config.OpenStackConfig{}, "gardenlinux", "1312.3.0", "", nil)
+			err := extender(rt, &shoot)
+
+			// then
+			require.NoError(t, err)
+			require.Len(t, shoot.Spec.Provider.Workers, 1)
+
+			var workerConfig v1alpha1.WorkerConfig
+			err = json.Unmarshal(shoot.Spec.Provider.Workers[0].ProviderConfig.Raw, &workerConfig)
+			require.NoError(t, err)
+			assert.Equal(t, testCase.ExpectedWorkerConfig, workerConfig)
+		})
+	}
+}
+
+func TestProviderExtenderForOpenStack(t *testing.T) {
+	openStackConfig := config.OpenStackConfig{
+		Default: config.OpenStackRegionConfig{
+			FloatingPoolName: "FloatingIP-external-kyma-01",
+		},
+		Regions: map[string]config.OpenStackRegionConfig{
+			"eu-de-2": {FloatingPoolName: "FloatingIP-external-kyma-02", LoadBalancerProvider: "octavia"},
+		},
+	}
+
+	for tname, testCase := range map[string]struct {
+		Region                       string
+		Override                     *imv1.OpenStackProviderConfig
+		ExpectedFloatingPoolName     string
+		ExpectedLoadBalancerProvider string
+	}{
+		"Use default settings": {
+			Region:                       "eu-de-1",
+			ExpectedFloatingPoolName:     "FloatingIP-external-kyma-01",
+			ExpectedLoadBalancerProvider: "f5",
+		},
+		"Use settings of the region": {
+			Region:                       "eu-de-2",
+			ExpectedFloatingPoolName:     "FloatingIP-external-kyma-02",
+			ExpectedLoadBalancerProvider: "octavia",
+		},
+		"Use settings from Runtime": {
+			Region:                       "eu-de-2",
+			Override:                     &imv1.OpenStackProviderConfig{FloatingPoolName: ptr.To("FloatingIP-external-kyma-03")},
+			ExpectedFloatingPoolName:     "FloatingIP-external-kyma-03",
+			ExpectedLoadBalancerProvider: "octavia",
+		},
+	} {
+		t.Run(tname, func(t *testing.T) {
+			// given
+			shoot := fixEmptyGardenerShoot("cluster", "kcp-system")
+			runtime := imv1.Runtime{
+				Spec: imv1.RuntimeSpec{
+					Shoot: imv1.RuntimeShoot{
+						Region: testCase.Region,
+						Provider: imv1.Provider{
+							Type:      hyperscaler.TypeOpenStack,
+							OpenStack: testCase.Override,
+							Workers: []gardener.Worker{
+								{Name: "worker", Minimum: 1, Maximum: 3, Zones: []string{testCase.Region + "a"}},
+							},
+						},
+						Networking: imv1.Networking{
+							Nodes: "10.250.0.0/16",
+						},
+					},
+				},
+			}
+
+			// when
+			extender := NewProviderExtender(false, openStackConfig, "gardenlinux", "1312.3.0", "", nil)
+			err := extender(runtime, &shoot)
+
+			// then
+			require.NoError(t, err)
+
+			var infrastructureConfig openstackv1alpha1.InfrastructureConfig
+			err = json.Unmarshal(shoot.Spec.Provider.InfrastructureConfig.Raw, &infrastructureConfig)
+			require.NoError(t, err)
+			assert.Equal(t, testCase.ExpectedFloatingPoolName, infrastructureConfig.FloatingPoolName)
+
+			var controlPlaneConfig openstackv1alpha1.ControlPlaneConfig
+			err = json.Unmarshal(shoot.Spec.Provider.ControlPlaneConfig.Raw, &controlPlaneConfig)
+			require.NoError(t, err)
+			assert.Equal(t, testCase.ExpectedLoadBalancerProvider, controlPlaneConfig.LoadBalancerProvider)
+		})
+	}
+}
+
 func fixAWSProvider(machineImageVersion string) imv1.Provider {
 	return imv1.Provider{
 		Type: hyperscaler.TypeAWS,
@@ -179,7 +415,14 @@ func fixAWSProviderWithMultipleWorkers() imv1.Provider {
 
 func assertProvider(t *testing.T, runtimeShoot imv1.RuntimeShoot, shoot gardener.Shoot, expectWorkerConfig bool, expectedMachineImageName, expectedMachineImageVersion string) {
 	assert.Equal(t, runtimeShoot.Provider.Type, shoot.Spec.Provider.Type)
-	assert.Equal(t, runtimeShoot.Provider.Workers, shoot.Spec.Provider.Workers)
+	require.Len(t, shoot.Spec.Provider.Workers, len(runtimeShoot.Provider.Workers))
+	for i, worker := range runtimeShoot.Provider.Workers {
+		assert.Equal(t, worker.Name, shoot.Spec.Provider.Workers[i].Name)
+		assert.Equal(t, worker.Machine.Type, shoot.Spec.Provider.Workers[i].Machine.Type)
+		assert.Equal(t, worker.Minimum, shoot.Spec.Provider.Workers[i].Minimum)
+		assert.Equal(t, worker.Maximum, shoot.Spec.Provider.Workers[i].Maximum)
+		assert.Equal(t, worker.Zones, shoot.Spec.Provider.Workers[i].Zones)
+	}
 	assert.Equal(t, false, shoot.Spec.Provider.WorkersSettings.SSHAccess.Enabled)
 	assert.NotEmpty(t, shoot.Spec.Provider.InfrastructureConfig)
 	assert.NotEmpty(t, shoot.Spec.Provider.InfrastructureConfig.Raw)
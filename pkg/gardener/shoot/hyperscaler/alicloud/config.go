@@ -0,0 +1,64 @@
+package alicloud
+
+import (
+	"encoding/json"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	apiVersion               = "alicloud.provider.extensions.gardener.cloud/v1alpha1"
+	infrastructureConfigKind = "InfrastructureConfig"
+	controlPlaneConfigKind   = "ControlPlaneConfig"
+)
+
+func GetInfrastructureConfig(workersCidr string, zones []string) ([]byte, error) {
+	return GetInfrastructureConfigWithZoneBits(workersCidr, zones, nil)
+}
+
+// GetInfrastructureConfigWithZoneBits creates the infrastructure config with the number of zones of the VPC CIDR requested in the Runtime
+func GetInfrastructureConfigWithZoneBits(workersCidr string, zones []string, zoneBits *int) ([]byte, error) {
+	infrastructureConfig, err := NewInfrastructureConfigWithZoneBits(workersCidr, zones, zoneBits)
+	if err != nil {
+		return nil, err
+	}
+
+	return json.Marshal(infrastructureConfig)
+}
+
+func GetControlPlaneConfig(_ []string) ([]byte, error) {
+	return json.Marshal(NewControlPlaneConfig())
+}
+
+func NewInfrastructureConfig(workersCidr string, zones []string) (InfrastructureConfig, error) {
+	return NewInfrastructureConfigWithZoneBits(workersCidr, zones, nil)
+}
+
+func NewInfrastructureConfigWithZoneBits(workersCidr string, zones []string, zoneBits *int) (InfrastructureConfig, error) {
+	alicloudZones, err := generateAlicloudZones(workersCidr, zones, zoneBits)
+	if err != nil {
+		return InfrastructureConfig{}, err
+	}
+
+	return InfrastructureConfig{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       infrastructureConfigKind,
+			APIVersion: apiVersion,
+		},
+		Networks: Networks{
+			VPC: VPC{
+				CIDR: &workersCidr,
+			},
+			Zones: alicloudZones,
+		},
+	}, nil
+}
+
+func NewControlPlaneConfig() *ControlPlaneConfig {
+	return &ControlPlaneConfig{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       controlPlaneConfigKind,
+			APIVersion: apiVersion,
+		},
+	}
+}
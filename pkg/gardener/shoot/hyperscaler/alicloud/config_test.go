@@ -0,0 +1,76 @@
+package alicloud
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestControlPlaneConfig(t *testing.T) {
+	t.Run("Create Control Plane config", func(t *testing.T) {
+		// when
+		controlPlaneConfigBytes, err := GetControlPlaneConfig(nil)
+
+		// then
+		require.NoError(t, err)
+
+		var controlPlaneConfig ControlPlaneConfig
+		err = json.Unmarshal(controlPlaneConfigBytes, &controlPlaneConfig)
+		require.NoError(t, err)
+
+		assert.Equal(t, apiVersion, controlPlaneConfig.TypeMeta.APIVersion)
+		assert.Equal(t, controlPlaneConfigKind, controlPlaneConfig.TypeMeta.Kind)
+	})
+}
+
+func TestInfrastructureConfig(t *testing.T) {
+	for tname, tcase := range map[string]struct {
+		givenNodesCidr string
+		givenZoneNames []string
+		expectedZones  []Zone
+	}{
+		"Regular 10.250.0.0/16": {
+			givenNodesCidr: "10.250.0.0/16",
+			givenZoneNames: []string{
+				"cn-shanghai-b",
+				"cn-shanghai-g",
+				"cn-shanghai-l",
+			},
+			expectedZones: []Zone{
+				{Name: "cn-shanghai-b", Workers: "10.250.0.0/19"},
+				{Name: "cn-shanghai-g", Workers: "10.250.32.0/19"},
+				{Name: "cn-shanghai-l", Workers: "10.250.64.0/19"},
+			},
+		},
+		"Regular 10.180.0.0/23": {
+			givenNodesCidr: "10.180.0.0/23",
+			givenZoneNames: []string{
+				"cn-beijing-h",
+				"cn-beijing-i",
+			},
+			expectedZones: []Zone{
+				{Name: "cn-beijing-h", Workers: "10.180.0.0/26"},
+				{Name: "cn-beijing-i", Workers: "10.180.0.64/26"},
+			},
+		},
+	} {
+		t.Run(tname, func(t *testing.T) {
+			// when
+			infrastructureConfigBytes, err := GetInfrastructureConfig(tcase.givenNodesCidr, tcase.givenZoneNames)
+
+			// then
+			require.NoError(t, err)
+
+			var infrastructureConfig InfrastructureConfig
+			err = json.Unmarshal(infrastructureConfigBytes, &infrastructureConfig)
+			require.NoError(t, err)
+
+			assert.Equal(t, apiVersion, infrastructureConfig.TypeMeta.APIVersion)
+			assert.Equal(t, infrastructureConfigKind, infrastructureConfig.TypeMeta.Kind)
+			assert.Equal(t, tcase.givenNodesCidr, *infrastructureConfig.Networks.VPC.CIDR)
+			assert.Equal(t, tcase.expectedZones, infrastructureConfig.Networks.Zones)
+		})
+	}
+}
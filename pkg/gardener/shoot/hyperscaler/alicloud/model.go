@@ -0,0 +1,51 @@
+package alicloud
+
+import metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+// This types are copied from https://github.com/gardener/gardener-extension-provider-alicloud/blob/master/pkg/apis/alicloud/v1alpha1/types_infrastructure.go
+// and https://github.com/gardener/gardener-extension-provider-alicloud/blob/master/pkg/apis/alicloud/v1alpha1/types_controlplane.go
+// as the provider extension is not a dependency of this module
+
+// InfrastructureConfig infrastructure configuration resource
+type InfrastructureConfig struct {
+	metav1.TypeMeta `json:",inline"`
+	// Networks specifies the networks for an infrastructure.
+	Networks Networks `json:"networks"`
+}
+
+// Networks specifies the networks for an infrastructure.
+type Networks struct {
+	// VPC contains information about whether to create a new or use an existing VPC.
+	VPC VPC `json:"vpc"`
+	// Zones are the network zones for an infrastructure.
+	Zones []Zone `json:"zones"`
+}
+
+// VPC contains information about whether to create a new or use an existing VPC.
+type VPC struct {
+	// ID is the ID of an existing VPC.
+	ID *string `json:"id,omitempty"`
+	// CIDR is the CIDR of a VPC to create.
+	CIDR *string `json:"cidr,omitempty"`
+}
+
+// Zone is a zone with a name and worker CIDR.
+type Zone struct {
+	// Name is the name of a zone.
+	Name string `json:"name"`
+	// Workers is the CIDR range used for the zone's workers.
+	Workers string `json:"workers"`
+}
+
+// ControlPlaneConfig contains configuration settings for the control plane.
+type ControlPlaneConfig struct {
+	metav1.TypeMeta `json:",inline"`
+	// CSI is the config for CSI plugin
+	CSI *CSI `json:"csi,omitempty"`
+}
+
+// CSI is csi components configuration.
+type CSI struct {
+	// EnableADController enables disks to be attached/detached from node using CSI Plugin
+	EnableADController *bool `json:"enableADController,omitempty"`
+}
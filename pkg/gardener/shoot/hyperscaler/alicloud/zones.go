@@ -0,0 +1,43 @@
+package alicloud
+
+import "github.com/kyma-project/infrastructure-manager/pkg/gardener/shoot/hyperscaler"
+
+// every zone takes a single workers subnet, the VPC CIDR holds at most 8 zones unless the Runtime sets the zone bits
+var subnetLayout = hyperscaler.SubnetLayout{
+	ZoneBits:   3,
+	SubnetBits: []int{0},
+}
+
+// ValidateWorkersCIDR checks that the subnets of all the zones fit into the workers CIDR
+func ValidateWorkersCIDR(workersCidr string, zones []string, zoneBits *int) error {
+	_, err := subnetLayout.WithZoneBits(zoneBits).Plan(workersCidr, len(zones))
+	return err
+}
+
+/*
+*
+generateAlicloudZones - creates a list of zones with non overlapping worker subnets inside of the VPC cidr block. example values:
+cidr: 10.250.0.0/16
+  - name: cn-shanghai-b
+    workers: 10.250.0.0/19
+  - name: cn-shanghai-g
+    workers: 10.250.32.0/19
+  - name: cn-shanghai-l
+    workers: 10.250.64.0/19
+*/
+func generateAlicloudZones(workerCidr string, zoneNames []string, zoneBits *int) ([]Zone, error) {
+	zoneSubnets, err := subnetLayout.WithZoneBits(zoneBits).Plan(workerCidr, len(zoneNames))
+	if err != nil {
+		return nil, err
+	}
+
+	var zones []Zone
+	for i, name := range zoneNames {
+		zones = append(zones, Zone{
+			Name:    name,
+			Workers: zoneSubnets[i][0].String(),
+		})
+	}
+
+	return zones, nil
+}
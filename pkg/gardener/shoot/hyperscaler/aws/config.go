@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 
 	"github.com/gardener/gardener-extension-provider-aws/pkg/apis/aws/v1alpha1"
+	"github.com/kyma-project/infrastructure-manager/pkg/gardener/shoot/hyperscaler"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
@@ -15,8 +16,25 @@ const workerConfigKind = "WorkerConfig"
 
 const awsIMDSv2HTTPPutResponseHopLimit int64 = 2
 
+// Options are the Runtime specific settings of the AWS infrastructure
+type Options struct {
+	// VPCID is the ID of the existing VPC the shoot is attached to, a new VPC is created if empty
+	VPCID string
+	// ZoneBits overrides the number of zones the workers CIDR is split into, see hyperscaler.SubnetLayout
+	ZoneBits *int
+}
+
 func GetInfrastructureConfig(workersCidr string, zones []string) ([]byte, error) {
-	return json.Marshal(NewInfrastructureConfig(workersCidr, zones))
+	return GetInfrastructureConfigWithOptions(workersCidr, zones, Options{})
+}
+
+func GetInfrastructureConfigWithOptions(workersCidr string, zones []string, options Options) ([]byte, error) {
+	infrastructureConfig, err := NewInfrastructureConfigWithOptions(workersCidr, zones, options)
+	if err != nil {
+		return nil, err
+	}
+
+	return json.Marshal(infrastructureConfig)
 }
 
 func GetControlPlaneConfig(_ []string) ([]byte, error) {
@@ -27,19 +45,40 @@ func GetWorkerConfig() ([]byte, error) {
 	return json.Marshal(NewWorkerConfig())
 }
 
-func NewInfrastructureConfig(workersCidr string, zones []string) v1alpha1.InfrastructureConfig {
+// ValidateWorkerConfig checks that the worker provider config set in the Runtime is an AWS WorkerConfig
+func ValidateWorkerConfig(workerConfig []byte) error {
+	return hyperscaler.DecodeProviderConfig(workerConfig, apiVersion, workerConfigKind, &v1alpha1.WorkerConfig{})
+}
+
+func NewInfrastructureConfig(workersCidr string, zones []string) (v1alpha1.InfrastructureConfig, error) {
+	return NewInfrastructureConfigWithOptions(workersCidr, zones, Options{})
+}
+
+func NewInfrastructureConfigWithOptions(workersCidr string, zones []string, options Options) (v1alpha1.InfrastructureConfig, error) {
+	awsZones, err := generateAWSZones(workersCidr, zones, options.ZoneBits)
+	if err != nil {
+		return v1alpha1.InfrastructureConfig{}, err
+	}
+
+	vpc := v1alpha1.VPC{
+		CIDR: &workersCidr,
+	}
+	if options.VPCID != "" {
+		vpc = v1alpha1.VPC{
+			ID: &options.VPCID,
+		}
+	}
+
 	return v1alpha1.InfrastructureConfig{
 		TypeMeta: metav1.TypeMeta{
 			Kind:       infrastructureConfigKind,
 			APIVersion: apiVersion,
 		},
 		Networks: v1alpha1.Networks{
-			Zones: generateAWSZones(workersCidr, zones),
-			VPC: v1alpha1.VPC{
-				CIDR: &workersCidr,
-			},
+			Zones: awsZones,
+			VPC:   vpc,
 		},
-	}
+	}, nil
 }
 
 func NewControlPlaneConfig() *v1alpha1.ControlPlaneConfig {
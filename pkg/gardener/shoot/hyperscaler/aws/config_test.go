@@ -7,6 +7,7 @@ import (
 	"github.com/gardener/gardener-extension-provider-aws/pkg/apis/aws/v1alpha1"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"k8s.io/utils/ptr"
 )
 
 func TestControlPlaneConfig(t *testing.T) {
@@ -30,6 +31,7 @@ func TestInfrastructureConfig(t *testing.T) {
 	for tname, tcase := range map[string]struct {
 		givenNodesCidr   string
 		givenZoneNames   []string
+		givenZoneBits    *int
 		expectedAwsZones []v1alpha1.Zone
 	}{
 		"Regular 10.250.0.0/16": {
@@ -88,10 +90,32 @@ func TestInfrastructureConfig(t *testing.T) {
 				},
 			},
 		},
+		"Zone bits requested in Runtime 10.250.0.0/16": {
+			givenNodesCidr: "10.250.0.0/16",
+			givenZoneNames: []string{
+				"eu-central-1a",
+				"eu-central-1b",
+			},
+			givenZoneBits: ptr.To(1),
+			expectedAwsZones: []v1alpha1.Zone{
+				{
+					Name:     "eu-central-1a",
+					Workers:  "10.250.0.0/18",
+					Public:   "10.250.64.0/19",
+					Internal: "10.250.96.0/19",
+				},
+				{
+					Name:     "eu-central-1b",
+					Workers:  "10.250.128.0/18",
+					Public:   "10.250.192.0/19",
+					Internal: "10.250.224.0/19",
+				},
+			},
+		},
 	} {
 		t.Run(tname, func(t *testing.T) {
 			// when
-			infrastructureConfigBytes, err := GetInfrastructureConfig(tcase.givenNodesCidr, tcase.givenZoneNames)
+			infrastructureConfigBytes, err := GetInfrastructureConfigWithOptions(tcase.givenNodesCidr, tcase.givenZoneNames, Options{ZoneBits: tcase.givenZoneBits})
 
 			// then
 			assert.NoError(t, err)
@@ -136,3 +160,90 @@ func TestWorkerConfig(t *testing.T) {
 		assert.Equal(t, v1alpha1.HTTPTokensRequired, *config.InstanceMetadataOptions.HTTPTokens)
 	})
 }
+
+func TestInfrastructureConfigForExistingVPC(t *testing.T) {
+	t.Run("Create infrastructure config for existing VPC", func(t *testing.T) {
+		// when
+		infrastructureConfigBytes, err := GetInfrastructureConfigWithOptions("10.250.0.0/16", []string{"eu-central-1a", "eu-central-1b"}, Options{VPCID: "vpc-123456"})
+
+		// then
+		require.NoError(t, err)
+
+		var infrastructureConfig v1alpha1.InfrastructureConfig
+		err = json.Unmarshal(infrastructureConfigBytes, &infrastructureConfig)
+		require.NoError(t, err)
+
+		assert.Equal(t, "vpc-123456", *infrastructureConfig.Networks.VPC.ID)
+		assert.Nil(t, infrastructureConfig.Networks.VPC.CIDR)
+		require.Len(t, infrastructureConfig.Networks.Zones, 2)
+		assertIPRanges(t, v1alpha1.Zone{
+			Name:     "eu-central-1a",
+			Workers:  "10.250.0.0/19",
+			Public:   "10.250.32.0/20",
+			Internal: "10.250.48.0/20",
+		}, infrastructureConfig.Networks.Zones[0])
+	})
+
+	t.Run("Reject workers CIDR which cannot hold the zone subnets", func(t *testing.T) {
+		// when
+		_, err := GetInfrastructureConfigWithOptions("10.250.0.0/29", []string{"eu-central-1a"}, Options{VPCID: "vpc-123456"})
+
+		// then
+		require.Error(t, err)
+	})
+}
+
+func TestValidateWorkersCIDR(t *testing.T) {
+	for tname, tcase := range map[string]struct {
+		givenWorkersCidr string
+		givenZoneNames   []string
+		givenZoneBits    *int
+		expectError      bool
+	}{
+		"Accept CIDR with the subnets of all zones": {
+			givenWorkersCidr: "10.250.0.0/22",
+			givenZoneNames:   []string{"eu-central-1a", "eu-central-1b", "eu-central-1c"},
+		},
+		"Reject invalid CIDR": {
+			givenWorkersCidr: "10.250.0.0",
+			expectError:      true,
+		},
+		"Reject IPv6 CIDR": {
+			givenWorkersCidr: "2001:db8::/64",
+			expectError:      true,
+		},
+		"Reject too small CIDR": {
+			givenWorkersCidr: "10.250.0.0/29",
+			givenZoneNames:   []string{"eu-central-1a"},
+			expectError:      true,
+		},
+		"Reject too many zones": {
+			givenWorkersCidr: "10.250.0.0/22",
+			givenZoneNames:   []string{"eu-central-1a", "eu-central-1b", "eu-central-1c", "eu-central-1d", "eu-central-1e"},
+			expectError:      true,
+		},
+		"Accept more zones with zone bits requested in Runtime": {
+			givenWorkersCidr: "10.250.0.0/22",
+			givenZoneNames:   []string{"eu-central-1a", "eu-central-1b", "eu-central-1c", "eu-central-1d", "eu-central-1e"},
+			givenZoneBits:    ptr.To(3),
+		},
+		"Reject too many zones for zone bits requested in Runtime": {
+			givenWorkersCidr: "10.250.0.0/22",
+			givenZoneNames:   []string{"eu-central-1a", "eu-central-1b", "eu-central-1c"},
+			givenZoneBits:    ptr.To(1),
+			expectError:      true,
+		},
+	} {
+		t.Run(tname, func(t *testing.T) {
+			// when
+			err := ValidateWorkersCIDR(tcase.givenWorkersCidr, tcase.givenZoneNames, tcase.givenZoneBits)
+
+			// then
+			if tcase.expectError {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
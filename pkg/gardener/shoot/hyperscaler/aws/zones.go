@@ -1,14 +1,22 @@
 package aws
 
 import (
-	"math/big"
-	"net/netip"
-
 	"github.com/gardener/gardener-extension-provider-aws/pkg/apis/aws/v1alpha1"
+	"github.com/kyma-project/infrastructure-manager/pkg/gardener/shoot/hyperscaler"
 )
 
-const workersBits = 3
-const lastBitNumber = 31
+// every zone takes the workers subnet and the public and internal subnets of half of its size,
+// the workers CIDR holds at most 4 zones unless the Runtime sets the zone bits
+var subnetLayout = hyperscaler.SubnetLayout{
+	ZoneBits:   2,
+	SubnetBits: []int{1, 2, 2},
+}
+
+// ValidateWorkersCIDR checks that the subnets of all the zones fit into the workers CIDR
+func ValidateWorkersCIDR(workersCidr string, zones []string, zoneBits *int) error {
+	_, err := subnetLayout.WithZoneBits(zoneBits).Plan(workersCidr, len(zones))
+	return err
+}
 
 /*
 *
@@ -28,46 +36,21 @@ cidr: 10.250.0.0/16
     public: 10.250.160.0/20
     internal: 10.250.176.0/20
 */
-func generateAWSZones(workerCidr string, zoneNames []string) []v1alpha1.Zone {
-	var zones []v1alpha1.Zone
-
-	cidr, _ := netip.ParsePrefix(workerCidr)
-	workerPrefixLength := cidr.Bits() + workersBits
-	workerPrefix, _ := cidr.Addr().Prefix(workerPrefixLength)
-
-	// delta - it is the difference between "public" and "internal" CIDRs, for example:
-	//    WorkerCidr:   "10.250.0.0/19",
-	//    PublicCidr:   "10.250.32.0/20",
-	//    InternalCidr: "10.250.48.0/20",
-	// 4 * delta  - difference between two worker (zone) CIDRs
-	delta := big.NewInt(1)
-	delta.Lsh(delta, uint(lastBitNumber-workerPrefixLength))
-
-	// base - it is an integer, which is based on IP bytes
-	base := new(big.Int).SetBytes(workerPrefix.Addr().AsSlice())
-
-	for _, name := range zoneNames {
-		zoneWorkerIP, _ := netip.AddrFromSlice(base.Bytes())
-		zoneWorkerCidr := netip.PrefixFrom(zoneWorkerIP, workerPrefixLength)
-
-		base.Add(base, delta)
-		base.Add(base, delta)
-		publicIP, _ := netip.AddrFromSlice(base.Bytes())
-		public := netip.PrefixFrom(publicIP, workerPrefixLength+1)
-
-		base.Add(base, delta)
-		internalIP, _ := netip.AddrFromSlice(base.Bytes())
-		internalPrefix := netip.PrefixFrom(internalIP, workerPrefixLength+1)
+func generateAWSZones(workerCidr string, zoneNames []string, zoneBits *int) ([]v1alpha1.Zone, error) {
+	zoneSubnets, err := subnetLayout.WithZoneBits(zoneBits).Plan(workerCidr, len(zoneNames))
+	if err != nil {
+		return nil, err
+	}
 
+	var zones []v1alpha1.Zone
+	for i, name := range zoneNames {
 		zones = append(zones, v1alpha1.Zone{
 			Name:     name,
-			Workers:  zoneWorkerCidr.String(),
-			Public:   public.String(),
-			Internal: internalPrefix.String(),
+			Workers:  zoneSubnets[i][0].String(),
+			Public:   zoneSubnets[i][1].String(),
+			Internal: zoneSubnets[i][2].String(),
 		})
-
-		base.Add(base, delta)
 	}
 
-	return zones
+	return zones, nil
 }
@@ -3,15 +3,32 @@ package azure
 import (
 	"encoding/json"
 
+	"github.com/kyma-project/infrastructure-manager/pkg/gardener/shoot/hyperscaler"
+
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 const infrastructureConfigKind = "InfrastructureConfig"
 const controlPlaneConfigKind = "ControlPlaneConfig"
+const workerConfigKind = "WorkerConfig"
 const apiVersion = "azure.provider.extensions.gardener.cloud/v1alpha1"
 
 func GetInfrastructureConfig(workerCIDR string, zones []string) ([]byte, error) {
-	return json.Marshal(NewInfrastructureConfig(workerCIDR, zones))
+	return GetInfrastructureConfigWithOptions(workerCIDR, zones, DefaultInfrastructureOptions())
+}
+
+func GetInfrastructureConfigWithOptions(workerCIDR string, zones []string, options InfrastructureOptions) ([]byte, error) {
+	infrastructureConfig, err := NewInfrastructureConfigWithOptions(workerCIDR, zones, options)
+	if err != nil {
+		return nil, err
+	}
+
+	return json.Marshal(infrastructureConfig)
+}
+
+// ValidateWorkerConfig checks that the worker provider config set in the Runtime is an Azure WorkerConfig
+func ValidateWorkerConfig(workerConfig []byte) error {
+	return hyperscaler.DecodeProviderConfig(workerConfig, apiVersion, workerConfigKind, &WorkerConfig{})
 }
 
 func GetControlPlaneConfig(_ []string) ([]byte, error) {
@@ -27,7 +44,11 @@ func NewControlPlaneConfig() *ControlPlaneConfig {
 	}
 }
 
-func NewInfrastructureConfig(workerCIDR string, zones []string) InfrastructureConfig {
+func NewInfrastructureConfig(workerCIDR string, zones []string) (InfrastructureConfig, error) {
+	return NewInfrastructureConfigWithOptions(workerCIDR, zones, DefaultInfrastructureOptions())
+}
+
+func NewInfrastructureConfigWithOptions(workerCIDR string, zones []string, options InfrastructureOptions) (InfrastructureConfig, error) {
 	// All Azure shoots are zoned.
 	// No zones - the shoot configuration is invalid.
 	// We should validate the config before calling this function.
@@ -46,7 +67,18 @@ func NewInfrastructureConfig(workerCIDR string, zones []string) InfrastructureCo
 		Zoned: isZoned,
 	}
 
-	azureConfig.Networks.Zones = generateAzureZones(workerCIDR, zones)
+	if options.ExistingVNet != nil {
+		azureConfig.Networks.VNet = VNet{
+			Name:          &options.ExistingVNet.Name,
+			ResourceGroup: &options.ExistingVNet.ResourceGroup,
+		}
+	}
+
+	azureZones, err := generateAzureZones(workerCIDR, zones, options.NatGateway, options.ZoneBits)
+	if err != nil {
+		return InfrastructureConfig{}, err
+	}
+	azureConfig.Networks.Zones = azureZones
 
-	return azureConfig
+	return azureConfig, nil
 }
@@ -149,3 +149,123 @@ func assertAzureZoneCidrs(t *testing.T, expectedZone Zone, actualZone Zone) {
 	assert.Equal(t, expectedZone.NatGateway.Enabled, actualZone.NatGateway.Enabled)
 	assert.Equal(t, expectedZone.NatGateway.IdleConnectionTimeoutMinutes, actualZone.NatGateway.IdleConnectionTimeoutMinutes)
 }
+
+func TestValidateZoneNames(t *testing.T) {
+	for tname, tcase := range map[string]struct {
+		givenZoneNames []string
+		expectError    bool
+	}{
+		"Accept all supported zones": {
+			givenZoneNames: []string{"1", "2", "3"},
+		},
+		"Reject empty zone list": {
+			givenZoneNames: []string{},
+			expectError:    true,
+		},
+		"Reject zone out of range": {
+			givenZoneNames: []string{"1", "4"},
+			expectError:    true,
+		},
+		"Reject zone which is not a number": {
+			givenZoneNames: []string{"westeurope-1"},
+			expectError:    true,
+		},
+	} {
+		t.Run(tname, func(t *testing.T) {
+			// when
+			err := ValidateZoneNames(tcase.givenZoneNames)
+
+			// then
+			if tcase.expectError {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestInfrastructureConfigForExistingVNet(t *testing.T) {
+	options := DefaultInfrastructureOptions()
+	options.ExistingVNet = &ExistingVNet{Name: "vnet", ResourceGroup: "vnet-rg"}
+
+	t.Run("Create infrastructure config for existing VNet", func(t *testing.T) {
+		// when
+		infrastructureConfigBytes, err := GetInfrastructureConfigWithOptions(DefaultNodesCIDR, []string{"1", "2"}, options)
+
+		// then
+		require.NoError(t, err)
+
+		var infrastructureConfig InfrastructureConfig
+		err = json.Unmarshal(infrastructureConfigBytes, &infrastructureConfig)
+		require.NoError(t, err)
+
+		assert.Equal(t, "vnet", *infrastructureConfig.Networks.VNet.Name)
+		assert.Equal(t, "vnet-rg", *infrastructureConfig.Networks.VNet.ResourceGroup)
+		assert.Len(t, infrastructureConfig.Networks.Zones, 2)
+	})
+
+	t.Run("Reject workers CIDR which cannot hold the zone subnets", func(t *testing.T) {
+		// when
+		_, err := GetInfrastructureConfigWithOptions("10.250.0.0/30", []string{"1"}, options)
+
+		// then
+		require.Error(t, err)
+	})
+}
+
+func TestInfrastructureConfigWithOptions(t *testing.T) {
+	t.Run("Configure NAT gateways of all zones", func(t *testing.T) {
+		// given
+		options := DefaultInfrastructureOptions()
+		options.NatGateway.IdleConnectionTimeoutMinutes = 30
+		options.NatGateway.IPAddresses = []PublicIPReference{
+			{Name: "ip-1", ResourceGroup: "ip-rg", Zone: 1},
+			{Name: "ip-3", ResourceGroup: "ip-rg", Zone: 3},
+		}
+
+		// when
+		infrastructureConfig, err := NewInfrastructureConfigWithOptions(DefaultNodesCIDR, []string{"1", "2", "3"}, options)
+
+		// then
+		require.NoError(t, err)
+		require.Len(t, infrastructureConfig.Networks.Zones, 3)
+
+		for _, zone := range infrastructureConfig.Networks.Zones {
+			assert.True(t, zone.NatGateway.Enabled)
+			assert.Equal(t, 30, zone.NatGateway.IdleConnectionTimeoutMinutes)
+		}
+		assert.Equal(t, []PublicIPReference{{Name: "ip-1", ResourceGroup: "ip-rg", Zone: 1}}, infrastructureConfig.Networks.Zones[0].NatGateway.IPAddresses)
+		assert.Empty(t, infrastructureConfig.Networks.Zones[1].NatGateway.IPAddresses)
+		assert.Equal(t, []PublicIPReference{{Name: "ip-3", ResourceGroup: "ip-rg", Zone: 3}}, infrastructureConfig.Networks.Zones[2].NatGateway.IPAddresses)
+	})
+
+	t.Run("Disable NAT gateways", func(t *testing.T) {
+		// given
+		options := DefaultInfrastructureOptions()
+		options.NatGateway.Enabled = false
+
+		// when
+		infrastructureConfig, err := NewInfrastructureConfigWithOptions(DefaultNodesCIDR, []string{"1", "2"}, options)
+
+		// then
+		require.NoError(t, err)
+		for _, zone := range infrastructureConfig.Networks.Zones {
+			assert.False(t, zone.NatGateway.Enabled)
+			assert.Zero(t, zone.NatGateway.IdleConnectionTimeoutMinutes)
+			assert.Empty(t, zone.NatGateway.IPAddresses)
+		}
+
+		raw, err := json.Marshal(infrastructureConfig)
+		require.NoError(t, err)
+		assert.NotContains(t, string(raw), "idleConnectionTimeoutMinutes")
+	})
+
+	t.Run("Return error for invalid zone name", func(t *testing.T) {
+		// when
+		_, err := GetInfrastructureConfig(DefaultNodesCIDR, []string{"1", "westeurope-2"})
+
+		// then
+		require.Error(t, err)
+	})
+}
@@ -1,6 +1,9 @@
 package azure
 
-import metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
 
 // This types are copied from https://github.com/gardener/gardener-extensions/blob/master/controllers/provider-azure/pkg/apis/azure/types_infrastructure.go as it does not contain json tags
 
@@ -56,7 +59,7 @@ type NatGateway struct {
 	// Enabled is an indicator if NAT gateway should be deployed.
 	Enabled bool `json:"enabled"`
 	// IdleConnectionTimeoutMinutes specifies the idle connection timeout limit for NAT gateway in minutes.
-	IdleConnectionTimeoutMinutes int `json:"idleConnectionTimeoutMinutes"`
+	IdleConnectionTimeoutMinutes int `json:"idleConnectionTimeoutMinutes,omitempty"`
 	// Zone specifies the zone in which the NAT gateway should be deployed to.
 	Zone int `json:"zone,omitempty"`
 	// IPAddresses is a list of ip addresses which should be assigned to the NAT gateway.
@@ -102,3 +105,60 @@ type CloudControllerManagerConfig struct {
 	// FeatureGates contains information about enabled feature gates.
 	FeatureGates map[string]bool
 }
+
+// This types are copied from https://github.com/gardener/gardener-extension-provider-azure/blob/master/pkg/apis/azure/v1alpha1/types_worker.go
+
+// WorkerConfig contains configuration settings for the worker nodes.
+type WorkerConfig struct {
+	metav1.TypeMeta `json:",inline"`
+
+	// NodeTemplate contains resource information of the machine which is used by Cluster Autoscaler to generate nodeTemplate during scaling a nodeGroup from zero.
+	// +optional
+	NodeTemplate *NodeTemplate `json:"nodeTemplate,omitempty"`
+	// DiagnosticsProfile specifies boot diagnostic options.
+	// +optional
+	DiagnosticsProfile *DiagnosticsProfile `json:"diagnosticsProfile,omitempty"`
+	// DataVolumes contains configuration for the additional disks attached to VMs.
+	// +optional
+	DataVolumes []DataVolume `json:"dataVolumes,omitempty"`
+}
+
+// NodeTemplate contains information about the expected node properties.
+type NodeTemplate struct {
+	// Capacity represents the expected Node capacity.
+	Capacity corev1.ResourceList `json:"capacity"`
+}
+
+// DiagnosticsProfile specifies boot diagnostic options.
+type DiagnosticsProfile struct {
+	// Enabled configures boot diagnostics to be stored or not.
+	Enabled bool `json:"enabled,omitempty"`
+	// StorageURI is the URI of the storage account to use for storing console output and screenshot.
+	// If not specified azure managed storage will be used.
+	StorageURI *string `json:"storageURI,omitempty"`
+}
+
+// DataVolume contains configuration for data volumes attached to VMs.
+type DataVolume struct {
+	// Name is the name of the data volume this configuration applies to.
+	Name string `json:"name"`
+	// ImageRef defines the dataVolume source image.
+	// +optional
+	ImageRef *Image `json:"imageRef,omitempty"`
+}
+
+// Image identifies the azure image.
+type Image struct {
+	// URN is the uniform resource name of the image, it has the format 'publisher:offer:sku:version'.
+	// +optional
+	URN *string `json:"urn,omitempty"`
+	// ID is the VM image ID
+	// +optional
+	ID *string `json:"id,omitempty"`
+	// CommunityGalleryImageID is the Community Image Gallery image id, it has the format '/CommunityGalleries/myGallery/Images/myImage/Versions/myVersion'
+	// +optional
+	CommunityGalleryImageID *string `json:"communityGalleryImageID,omitempty"`
+	// SharedGalleryImageID is the Shared Image Gallery image id, it has the format '/SharedGalleries/sharedGalleryName/Images/sharedGalleryImageName/Versions/sharedGalleryImageVersionName'
+	// +optional
+	SharedGalleryImageID *string `json:"sharedGalleryImageID,omitempty"`
+}
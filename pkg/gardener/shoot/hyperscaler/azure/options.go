@@ -0,0 +1,36 @@
+package azure
+
+const defaultConnectionTimeOutMinutes = 4
+
+// InfrastructureOptions are the Runtime specific settings of the Azure infrastructure
+type InfrastructureOptions struct {
+	// ExistingVNet is the VNet the shoot is attached to, a new VNet is created if not set
+	ExistingVNet *ExistingVNet
+	// NatGateway is applied to the NAT gateways of all zones
+	NatGateway NatGatewayOptions
+	// ZoneBits overrides the number of zones the workers CIDR is split into, see hyperscaler.SubnetLayout
+	ZoneBits *int
+}
+
+type ExistingVNet struct {
+	Name          string
+	ResourceGroup string
+}
+
+type NatGatewayOptions struct {
+	Enabled                      bool
+	IdleConnectionTimeoutMinutes int
+	// IPAddresses are assigned to the NAT gateways of the zones they are deployed to
+	IPAddresses []PublicIPReference
+}
+
+// DefaultInfrastructureOptions returns the options used for the Runtimes which do not customize the Azure infrastructure,
+// new Azure runtimes have the NAT gateway enabled in all zones
+func DefaultInfrastructureOptions() InfrastructureOptions {
+	return InfrastructureOptions{
+		NatGateway: NatGatewayOptions{
+			Enabled:                      true,
+			IdleConnectionTimeoutMinutes: defaultConnectionTimeOutMinutes,
+		},
+	}
+}
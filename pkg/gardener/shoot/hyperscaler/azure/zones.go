@@ -1,58 +1,107 @@
 package azure
 
 import (
-	"math/big"
-	"net/netip"
+	"fmt"
 	"strconv"
+
+	"github.com/kyma-project/infrastructure-manager/pkg/gardener/shoot/hyperscaler"
+	"github.com/pkg/errors"
 )
 
-const defaultConnectionTimeOutMinutes = 4
-const workersBits = 3
-const cidrLength = 32
+// every zone takes a single subnet, the workers CIDR holds at most 8 zones unless the Runtime sets the zone bits
+var subnetLayout = hyperscaler.SubnetLayout{
+	ZoneBits:   3,
+	SubnetBits: []int{0},
+}
 
-func generateAzureZones(workerCidr string, zoneNames []string) []Zone {
-	var zones []Zone
+func generateAzureZones(workerCidr string, zoneNames []string, natGateway NatGatewayOptions, zoneBits *int) ([]Zone, error) {
+	zoneNumbers, err := convertZoneNames(zoneNames)
+	if err != nil {
+		return nil, err
+	}
 
-	cidr, _ := netip.ParsePrefix(workerCidr)
-	workerPrefixLength := cidr.Bits() + workersBits
-	workerPrefix, _ := cidr.Addr().Prefix(workerPrefixLength)
-	// delta - it is the difference between CIDRs of two zones:
-	//    zone1:   "10.250.0.0/19",
-	//    zone2:   "10.250.32.0/19",
-	delta := big.NewInt(1)
-	delta.Lsh(delta, uint(cidrLength-workerPrefixLength))
-
-	// zoneIPValue - it is an integer, which is based on IP bytes
-	zoneIPValue := new(big.Int).SetBytes(workerPrefix.Addr().AsSlice())
-
-	for _, name := range convertZoneNames(zoneNames) {
-		zoneWorkerIP, _ := netip.AddrFromSlice(zoneIPValue.Bytes())
-		zoneWorkerCidr := netip.PrefixFrom(zoneWorkerIP, workerPrefixLength)
-		zoneIPValue.Add(zoneIPValue, delta)
+	zoneSubnets, err := subnetLayout.WithZoneBits(zoneBits).Plan(workerCidr, len(zoneNumbers))
+	if err != nil {
+		return nil, err
+	}
+
+	var zones []Zone
+	for i, name := range zoneNumbers {
 		zones = append(zones, Zone{
 			Name: name,
-			CIDR: zoneWorkerCidr.String(),
-			NatGateway: &NatGateway{
-				// There are existing Azure clusters which were created before NAT gateway support,
-				// and they were migrated to HA with all zones having enableNatGateway: false .
-				// But for new Azure runtimes, enableNatGateway for all zones is always true
-				Enabled:                      true,
-				IdleConnectionTimeoutMinutes: defaultConnectionTimeOutMinutes,
-			},
+			CIDR: zoneSubnets[i][0].String(),
+			// There are existing Azure clusters which were created before NAT gateway support,
+			// and they were migrated to HA with all zones having enableNatGateway: false .
+			// But for new Azure runtimes, enableNatGateway for all zones is true unless disabled in the Runtime
+			NatGateway: zoneNatGateway(natGateway, name),
 		})
 	}
-	return zones
+	return zones, nil
 }
 
-func convertZoneNames(zoneNames []string) []int {
+// zoneNatGateway sets the idle connection timeout and the public IPs only for the enabled NAT gateway, Gardener rejects them otherwise
+func zoneNatGateway(natGateway NatGatewayOptions, zone int) *NatGateway {
+	if !natGateway.Enabled {
+		return &NatGateway{Enabled: false}
+	}
+
+	return &NatGateway{
+		Enabled:                      true,
+		IdleConnectionTimeoutMinutes: natGateway.IdleConnectionTimeoutMinutes,
+		IPAddresses:                  zonePublicIPs(natGateway.IPAddresses, zone),
+	}
+}
+
+func zonePublicIPs(ipAddresses []PublicIPReference, zone int) []PublicIPReference {
+	var zoneIPAddresses []PublicIPReference
+	for _, ipAddress := range ipAddresses {
+		if int(ipAddress.Zone) == zone {
+			zoneIPAddresses = append(zoneIPAddresses, ipAddress)
+		}
+	}
+
+	return zoneIPAddresses
+}
+
+func convertZoneNames(zoneNames []string) ([]int, error) {
 	var zones []int
 	for _, inputZone := range zoneNames {
-		zone, err := strconv.Atoi(inputZone)
-		if err != nil || zone < 1 || zone > 3 {
-			continue
+		zone, err := parseZoneName(inputZone)
+		if err != nil {
+			return nil, err
 		}
 		zones = append(zones, zone)
 	}
 
-	return zones
+	return zones, nil
+}
+
+// ValidateWorkersCIDR checks that the subnets of all the zones fit into the workers CIDR
+func ValidateWorkersCIDR(workerCidr string, zoneNames []string, zoneBits *int) error {
+	zones, err := convertZoneNames(zoneNames)
+	if err != nil {
+		return err
+	}
+
+	_, err = subnetLayout.WithZoneBits(zoneBits).Plan(workerCidr, len(zones))
+	return err
+}
+
+// ValidateZoneNames checks that the zone list of an Azure worker is not empty and contains only zones 1-3
+func ValidateZoneNames(zoneNames []string) error {
+	if len(zoneNames) == 0 {
+		return errors.New("zones list is empty")
+	}
+
+	_, err := convertZoneNames(zoneNames)
+	return err
+}
+
+func parseZoneName(zoneName string) (int, error) {
+	zone, err := strconv.Atoi(zoneName)
+	if err != nil || zone < 1 || zone > 3 {
+		return 0, fmt.Errorf("invalid zone name %q, expected one of: 1, 2, 3", zoneName)
+	}
+
+	return zone, nil
 }
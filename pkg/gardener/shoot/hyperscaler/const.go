@@ -1,8 +1,20 @@
 package hyperscaler
 
+import "slices"
+
 const (
 	TypeAWS       = "aws"
 	TypeAzure     = "azure"
 	TypeGCP       = "gcp"
 	TypeOpenStack = "openstack"
+	TypeAlicloud  = "alicloud"
 )
+
+// SupportedTypes returns the provider types the shoot converter is able to handle.
+func SupportedTypes() []string {
+	return []string{TypeAWS, TypeAzure, TypeGCP, TypeOpenStack, TypeAlicloud}
+}
+
+func IsSupported(providerType string) bool {
+	return slices.Contains(SupportedTypes(), providerType)
+}
@@ -2,8 +2,11 @@ package gcp
 
 import (
 	"encoding/json"
+	"slices"
+	"strings"
 
 	"github.com/gardener/gardener-extension-provider-gcp/pkg/apis/gcp/v1alpha1"
+	"github.com/kyma-project/infrastructure-manager/pkg/gardener/shoot/hyperscaler"
 	"github.com/pkg/errors"
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
@@ -11,21 +14,76 @@ import (
 const (
 	infrastructureConfigKind = "InfrastructureConfig"
 	controlPlaneConfigKind   = "ControlPlaneConfig"
+	workerConfigKind         = "WorkerConfig"
 	apiVersion               = "gcp.provider.extensions.gardener.cloud/v1alpha1"
 )
 
+// InfrastructureOptions are the Runtime specific settings of the GCP network
+type InfrastructureOptions struct {
+	// InternalCIDR is the CIDR of the internal subnet, the subnet is not created if empty
+	InternalCIDR string
+	// CloudNAT is not configured if nil, Gardener defaults are used then
+	CloudNAT *CloudNATOptions
+}
+
+type CloudNATOptions struct {
+	MinPortsPerVM *int32
+	// NatIPNames are the names of the existing external IP addresses
+	NatIPNames []string
+}
+
 func GetInfrastructureConfig(workerCIDR string, _ []string) ([]byte, error) {
 	return json.Marshal(NewInfrastructureConfig(workerCIDR))
 }
 
+func GetInfrastructureConfigWithOptions(workerCIDR string, _ []string, options InfrastructureOptions) ([]byte, error) {
+	return json.Marshal(NewInfrastructureConfigWithOptions(workerCIDR, options))
+}
+
 func GetControlPlaneConfig(zones []string) ([]byte, error) {
-	if len(zones) == 0 {
-		return nil, errors.New("zones list is empty")
+	if err := ValidateZones(zones); err != nil {
+		return nil, err
 	}
 
 	return json.Marshal(NewControlPlaneConfig(zones))
 }
 
+// GetControlPlaneConfigForZone creates the control plane config with the control plane placed in the given worker zone
+func GetControlPlaneConfigForZone(controlPlaneZone string, zones []string) ([]byte, error) {
+	if err := ValidateControlPlaneZone(controlPlaneZone, zones); err != nil {
+		return nil, err
+	}
+
+	return json.Marshal(newControlPlaneConfig(controlPlaneZone))
+}
+
+// ValidateZones checks that at least one zone is configured, the control plane zone is taken from this list
+func ValidateZones(zones []string) error {
+	if len(zones) == 0 {
+		return errors.New("zones list is empty")
+	}
+
+	return nil
+}
+
+// ValidateWorkerConfig checks that the worker provider config set in the Runtime is a GCP WorkerConfig
+func ValidateWorkerConfig(workerConfig []byte) error {
+	return hyperscaler.DecodeProviderConfig(workerConfig, apiVersion, workerConfigKind, &v1alpha1.WorkerConfig{})
+}
+
+// ValidateControlPlaneZone checks that the control plane zone is one of the worker zones
+func ValidateControlPlaneZone(controlPlaneZone string, zones []string) error {
+	if err := ValidateZones(zones); err != nil {
+		return err
+	}
+
+	if !slices.Contains(zones, controlPlaneZone) {
+		return errors.Errorf("control plane zone %q is not one of the worker zones: %s", controlPlaneZone, strings.Join(zones, ", "))
+	}
+
+	return nil
+}
+
 func NewInfrastructureConfig(workerCIDR string) v1alpha1.InfrastructureConfig {
 	return v1alpha1.InfrastructureConfig{
 		TypeMeta: v1.TypeMeta{
@@ -41,12 +99,37 @@ func NewInfrastructureConfig(workerCIDR string) v1alpha1.InfrastructureConfig {
 	}
 }
 
+func NewInfrastructureConfigWithOptions(workerCIDR string, options InfrastructureOptions) v1alpha1.InfrastructureConfig {
+	infrastructureConfig := NewInfrastructureConfig(workerCIDR)
+
+	if options.InternalCIDR != "" {
+		infrastructureConfig.Networks.Internal = &options.InternalCIDR
+	}
+
+	if options.CloudNAT != nil {
+		cloudNAT := &v1alpha1.CloudNAT{
+			MinPortsPerVM: options.CloudNAT.MinPortsPerVM,
+		}
+		for _, name := range options.CloudNAT.NatIPNames {
+			cloudNAT.NatIPNames = append(cloudNAT.NatIPNames, v1alpha1.NatIPName{Name: name})
+		}
+		infrastructureConfig.Networks.CloudNAT = cloudNAT
+	}
+
+	return infrastructureConfig
+}
+
+// NewControlPlaneConfig places the control plane in the first zone
 func NewControlPlaneConfig(zones []string) *v1alpha1.ControlPlaneConfig {
+	return newControlPlaneConfig(zones[0])
+}
+
+func newControlPlaneConfig(zone string) *v1alpha1.ControlPlaneConfig {
 	return &v1alpha1.ControlPlaneConfig{
 		TypeMeta: v1.TypeMeta{
 			Kind:       controlPlaneConfigKind,
 			APIVersion: apiVersion,
 		},
-		Zone: zones[0],
+		Zone: zone,
 	}
 }
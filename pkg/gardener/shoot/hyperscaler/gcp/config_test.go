@@ -39,6 +39,27 @@ func TestControlPlaneConfig(t *testing.T) {
 		// then
 		require.Error(t, err)
 	})
+
+	t.Run("Create Control Plane config with the chosen zone", func(t *testing.T) {
+		// when
+		controlPlaneConfigBytes, err := GetControlPlaneConfigForZone("europe-west3b", []string{"europe-west3a", "europe-west3b"})
+
+		// then
+		require.NoError(t, err)
+
+		var controlPlaneConfig v1alpha1.ControlPlaneConfig
+		err = json.Unmarshal(controlPlaneConfigBytes, &controlPlaneConfig)
+		assert.NoError(t, err)
+		assert.Equal(t, "europe-west3b", controlPlaneConfig.Zone)
+	})
+
+	t.Run("Return error when the chosen zone is not a worker zone", func(t *testing.T) {
+		// when
+		_, err := GetControlPlaneConfigForZone("europe-west3c", []string{"europe-west3a", "europe-west3b"})
+
+		// then
+		require.Error(t, err)
+	})
 }
 
 func TestInfrastructureConfig(t *testing.T) {
@@ -57,3 +78,43 @@ func TestInfrastructureConfig(t *testing.T) {
 		assert.Equal(t, "10.250.0.0/22", infrastructureConfig.Networks.Worker)
 	})
 }
+
+func TestInfrastructureConfigWithOptions(t *testing.T) {
+	t.Run("Create Infrastructure config with internal subnet and Cloud NAT", func(t *testing.T) {
+		// given
+		minPortsPerVM := int32(2048)
+		options := InfrastructureOptions{
+			InternalCIDR: "10.251.0.0/24",
+			CloudNAT: &CloudNATOptions{
+				MinPortsPerVM: &minPortsPerVM,
+				NatIPNames:    []string{"nat-ip-1", "nat-ip-2"},
+			},
+		}
+
+		// when
+		infrastructureConfigBytes, err := GetInfrastructureConfigWithOptions("10.250.0.0/22", nil, options)
+
+		// then
+		require.NoError(t, err)
+
+		var infrastructureConfig v1alpha1.InfrastructureConfig
+		err = json.Unmarshal(infrastructureConfigBytes, &infrastructureConfig)
+		assert.NoError(t, err)
+
+		assert.Equal(t, "10.250.0.0/22", infrastructureConfig.Networks.Workers)
+		require.NotNil(t, infrastructureConfig.Networks.Internal)
+		assert.Equal(t, "10.251.0.0/24", *infrastructureConfig.Networks.Internal)
+		require.NotNil(t, infrastructureConfig.Networks.CloudNAT)
+		assert.Equal(t, int32(2048), *infrastructureConfig.Networks.CloudNAT.MinPortsPerVM)
+		assert.Equal(t, []v1alpha1.NatIPName{{Name: "nat-ip-1"}, {Name: "nat-ip-2"}}, infrastructureConfig.Networks.CloudNAT.NatIPNames)
+	})
+
+	t.Run("Create Infrastructure config without options", func(t *testing.T) {
+		// when
+		infrastructureConfig := NewInfrastructureConfigWithOptions("10.250.0.0/22", InfrastructureOptions{})
+
+		// then
+		assert.Nil(t, infrastructureConfig.Networks.Internal)
+		assert.Nil(t, infrastructureConfig.Networks.CloudNAT)
+	})
+}
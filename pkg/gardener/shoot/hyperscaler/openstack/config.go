@@ -15,33 +15,87 @@ const (
 	defaultLoadBalancerProvider = "f5"
 )
 
-func GetInfrastructureConfig(workerCIDR string, _ []string) ([]byte, error) {
-	return json.Marshal(NewInfrastructureConfig(workerCIDR))
+// Options are the region or Runtime specific settings of the OpenStack shoots, the defaults are used for the empty fields
+type Options struct {
+	FloatingPoolName     string
+	LoadBalancerProvider string
 }
 
-func GetControlPlaneConfig(_ []string) ([]byte, error) {
-	return json.Marshal(NewControlPlaneConfig())
+func GetInfrastructureConfig(workerCIDR string, zones []string) ([]byte, error) {
+	return GetInfrastructureConfigWithOptions(workerCIDR, zones, Options{})
+}
+
+func GetInfrastructureConfigWithOptions(workerCIDR string, _ []string, options Options) ([]byte, error) {
+	return json.Marshal(NewInfrastructureConfigWithOptions(workerCIDR, options))
+}
+
+func GetControlPlaneConfig(zones []string) ([]byte, error) {
+	return GetControlPlaneConfigWithOptions(zones, Options{})
+}
+
+func GetControlPlaneConfigWithOptions(_ []string, options Options) ([]byte, error) {
+	return json.Marshal(NewControlPlaneConfigWithOptions(options))
 }
 
 func NewInfrastructureConfig(workerCIDR string) v1alpha1.InfrastructureConfig {
+	return NewInfrastructureConfigWithOptions(workerCIDR, Options{})
+}
+
+func NewInfrastructureConfigWithOptions(workerCIDR string, options Options) v1alpha1.InfrastructureConfig {
+	floatingPoolName := options.FloatingPoolName
+	if floatingPoolName == "" {
+		floatingPoolName = defaultFloatingPoolName
+	}
+
 	return v1alpha1.InfrastructureConfig{
 		TypeMeta: v1.TypeMeta{
 			Kind:       infrastructureConfigKind,
 			APIVersion: apiVersion,
 		},
-		FloatingPoolName: defaultFloatingPoolName,
+		FloatingPoolName: floatingPoolName,
 		Networks: v1alpha1.Networks{
 			Workers: workerCIDR,
 		},
 	}
 }
 
+// KeepFloatingPoolName sets the floating pool name of the current infrastructure config in the desired one,
+// the floating pool of the existing shoot cannot be changed
+func KeepFloatingPoolName(desired, current []byte) ([]byte, error) {
+	var currentConfig v1alpha1.InfrastructureConfig
+	if err := json.Unmarshal(current, &currentConfig); err != nil {
+		return nil, err
+	}
+
+	if currentConfig.FloatingPoolName == "" {
+		return desired, nil
+	}
+
+	var desiredConfig v1alpha1.InfrastructureConfig
+	if err := json.Unmarshal(desired, &desiredConfig); err != nil {
+		return nil, err
+	}
+
+	desiredConfig.FloatingPoolName = currentConfig.FloatingPoolName
+
+	return json.Marshal(desiredConfig)
+}
+
 func NewControlPlaneConfig() *v1alpha1.ControlPlaneConfig {
+	return NewControlPlaneConfigWithOptions(Options{})
+}
+
+func NewControlPlaneConfigWithOptions(options Options) *v1alpha1.ControlPlaneConfig {
+	loadBalancerProvider := options.LoadBalancerProvider
+	if loadBalancerProvider == "" {
+		loadBalancerProvider = defaultLoadBalancerProvider
+	}
+
 	return &v1alpha1.ControlPlaneConfig{
 		TypeMeta: v1.TypeMeta{
 			Kind:       controlPlaneConfigKind,
 			APIVersion: apiVersion,
 		},
-		LoadBalancerProvider: defaultLoadBalancerProvider,
+		LoadBalancerProvider: loadBalancerProvider,
 	}
 }
@@ -45,3 +45,52 @@ func TestInfrastructureConfig(t *testing.T) {
 		assert.Equal(t, defaultFloatingPoolName, infrastructureConfig.FloatingPoolName)
 	})
 }
+
+func TestConfigWithOptions(t *testing.T) {
+	t.Run("Create configs with the given floating pool and load balancer provider", func(t *testing.T) {
+		// given
+		options := Options{
+			FloatingPoolName:     "FloatingIP-external-kyma-02",
+			LoadBalancerProvider: "octavia",
+		}
+
+		// when
+		infrastructureConfig := NewInfrastructureConfigWithOptions("10.250.0.0/22", options)
+		controlPlaneConfig := NewControlPlaneConfigWithOptions(options)
+
+		// then
+		assert.Equal(t, "FloatingIP-external-kyma-02", infrastructureConfig.FloatingPoolName)
+		assert.Equal(t, "octavia", controlPlaneConfig.LoadBalancerProvider)
+	})
+}
+
+func TestKeepFloatingPoolName(t *testing.T) {
+	t.Run("Keep floating pool name of existing shoot", func(t *testing.T) {
+		// given
+		desired, err := GetInfrastructureConfigWithOptions("10.250.0.0/22", nil, Options{FloatingPoolName: "FloatingIP-external-kyma-02"})
+		require.NoError(t, err)
+		current, err := GetInfrastructureConfig("10.250.0.0/22", nil)
+		require.NoError(t, err)
+
+		// when
+		infrastructureConfigBytes, err := KeepFloatingPoolName(desired, current)
+
+		// then
+		require.NoError(t, err)
+
+		var infrastructureConfig v1alpha1.InfrastructureConfig
+		err = json.Unmarshal(infrastructureConfigBytes, &infrastructureConfig)
+		require.NoError(t, err)
+
+		assert.Equal(t, defaultFloatingPoolName, infrastructureConfig.FloatingPoolName)
+		assert.Equal(t, "10.250.0.0/22", infrastructureConfig.Networks.Workers)
+	})
+
+	t.Run("Return error for invalid infrastructure config", func(t *testing.T) {
+		// when
+		_, err := KeepFloatingPoolName([]byte("{}"), []byte("invalid"))
+
+		// then
+		require.Error(t, err)
+	})
+}
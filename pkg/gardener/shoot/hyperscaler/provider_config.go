@@ -0,0 +1,29 @@
+package hyperscaler
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// DecodeProviderConfig decodes the provider config set in the Runtime, it must be of the given API version and kind and must not contain unknown fields
+func DecodeProviderConfig(providerConfig []byte, apiVersion, kind string, into any) error {
+	var typeMeta metav1.TypeMeta
+	if err := json.Unmarshal(providerConfig, &typeMeta); err != nil {
+		return fmt.Errorf("invalid provider config: %w", err)
+	}
+
+	if typeMeta.APIVersion != apiVersion || typeMeta.Kind != kind {
+		return fmt.Errorf("invalid provider config type %s/%s, expected %s/%s", typeMeta.APIVersion, typeMeta.Kind, apiVersion, kind)
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(providerConfig))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(into); err != nil {
+		return fmt.Errorf("invalid %s: %w", kind, err)
+	}
+
+	return nil
+}
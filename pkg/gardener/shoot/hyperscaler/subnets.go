@@ -0,0 +1,131 @@
+package hyperscaler
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math/bits"
+	"net/netip"
+	"slices"
+)
+
+const ipv4Bits = 32
+
+var (
+	ErrInvalidCIDR         = errors.New("invalid CIDR")
+	ErrCIDRTooSmall        = errors.New("CIDR is too small")
+	ErrTooManyZones        = errors.New("too many zones")
+	ErrInvalidSubnetLayout = errors.New("invalid subnet layout")
+)
+
+// SubnetLayout defines how the workers CIDR is split into the non overlapping subnets of the zones.
+// Every zone gets a block of the same size, the subnets of the zone are allocated one after another from the beginning of the block
+type SubnetLayout struct {
+	// ZoneBits is the number of bits added to the prefix length of the workers CIDR to get the block of a single zone,
+	// so the CIDR holds at most 2^ZoneBits zones. 0 means the smallest number of bits required for the given zone count
+	ZoneBits int
+	// SubnetBits is the number of bits added to the prefix length of the zone block for every subnet of the zone,
+	// e.g. {1, 2, 2} splits the block into a half and two quarters
+	SubnetBits []int
+}
+
+// ZoneSubnets are the subnets of a single zone, in the order of SubnetLayout.SubnetBits
+type ZoneSubnets []netip.Prefix
+
+// Plan computes the subnets of zoneCount zones inside of the IPv4 CIDR
+func (l SubnetLayout) Plan(cidr string, zoneCount int) ([]ZoneSubnets, error) {
+	prefix, err := netip.ParsePrefix(cidr)
+	if err != nil || !prefix.Addr().Is4() {
+		return nil, fmt.Errorf("%w %q, expected an IPv4 CIDR", ErrInvalidCIDR, cidr)
+	}
+
+	if l.ZoneBits < 0 {
+		return nil, fmt.Errorf("%w: zone bits must not be negative, got %d", ErrInvalidSubnetLayout, l.ZoneBits)
+	}
+
+	zoneBits := l.zoneBits(zoneCount)
+	if maxZones := 1 << zoneBits; zoneCount > maxZones {
+		return nil, fmt.Errorf("%w: CIDR %s holds the subnets of at most %d zones, got %d", ErrTooManyZones, cidr, maxZones, zoneCount)
+	}
+
+	offsets, err := l.subnetOffsets()
+	if err != nil {
+		return nil, err
+	}
+
+	zonePrefixLength := prefix.Bits() + zoneBits
+	if zonePrefixLength+slices.Max(append([]int{0}, l.SubnetBits...)) > ipv4Bits {
+		return nil, fmt.Errorf("%w: CIDR %s cannot be split into the subnets of %d zones", ErrCIDRTooSmall, cidr, zoneCount)
+	}
+
+	addr := prefix.Masked().Addr().As4()
+	base := uint64(binary.BigEndian.Uint32(addr[:]))
+	zoneSize := uint64(1) << (ipv4Bits - zonePrefixLength)
+
+	zones := make([]ZoneSubnets, 0, zoneCount)
+	for i := 0; i < zoneCount; i++ {
+		zoneBase := base + uint64(i)*zoneSize
+
+		subnets := make(ZoneSubnets, 0, len(l.SubnetBits))
+		for j, subnetBits := range l.SubnetBits {
+			// the offsets are relative to the block of a /0 prefix
+			offset := offsets[j] >> zonePrefixLength
+			subnets = append(subnets, netip.PrefixFrom(toAddr(zoneBase+offset), zonePrefixLength+subnetBits))
+		}
+		zones = append(zones, subnets)
+	}
+
+	return zones, nil
+}
+
+// WithZoneBits returns the layout with the zone bits requested in the Runtime, the layout is not changed if zoneBits is not set
+func (l SubnetLayout) WithZoneBits(zoneBits *int) SubnetLayout {
+	if zoneBits != nil {
+		l.ZoneBits = *zoneBits
+	}
+
+	return l
+}
+
+func (l SubnetLayout) zoneBits(zoneCount int) int {
+	if l.ZoneBits > 0 || zoneCount < 2 {
+		return l.ZoneBits
+	}
+
+	return bits.Len(uint(zoneCount - 1))
+}
+
+// subnetOffsets returns the offsets of the subnets inside of a zone block of the /0 prefix size,
+// every subnet must be aligned to its size and all of them must fit into the block
+func (l SubnetLayout) subnetOffsets() ([]uint64, error) {
+	blockSize := uint64(1) << ipv4Bits
+
+	var offset uint64
+	offsets := make([]uint64, 0, len(l.SubnetBits))
+	for _, subnetBits := range l.SubnetBits {
+		if subnetBits < 0 || subnetBits > ipv4Bits {
+			return nil, fmt.Errorf("%w: subnet bits must be between 0 and %d, got %d", ErrInvalidSubnetLayout, ipv4Bits, subnetBits)
+		}
+
+		size := blockSize >> subnetBits
+		if offset%size != 0 {
+			return nil, fmt.Errorf("%w: subnets must be ordered from the largest to the smallest", ErrInvalidSubnetLayout)
+		}
+
+		offsets = append(offsets, offset)
+		offset += size
+	}
+
+	if offset > blockSize {
+		return nil, fmt.Errorf("%w: subnets do not fit into the zone block", ErrInvalidSubnetLayout)
+	}
+
+	return offsets, nil
+}
+
+func toAddr(value uint64) netip.Addr {
+	var addr [4]byte
+	binary.BigEndian.PutUint32(addr[:], uint32(value))
+
+	return netip.AddrFrom4(addr)
+}
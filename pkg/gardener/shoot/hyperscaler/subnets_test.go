@@ -0,0 +1,117 @@
+package hyperscaler
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSubnetLayoutPlan(t *testing.T) {
+	for tname, tcase := range map[string]struct {
+		givenLayout     SubnetLayout
+		givenCidr       string
+		givenZoneCount  int
+		expectedSubnets [][]string
+		expectedErr     error
+	}{
+		"Split CIDR into zone blocks with multiple subnets": {
+			givenLayout:    SubnetLayout{ZoneBits: 2, SubnetBits: []int{1, 2, 2}},
+			givenCidr:      "10.250.0.0/16",
+			givenZoneCount: 3,
+			expectedSubnets: [][]string{
+				{"10.250.0.0/19", "10.250.32.0/20", "10.250.48.0/20"},
+				{"10.250.64.0/19", "10.250.96.0/20", "10.250.112.0/20"},
+				{"10.250.128.0/19", "10.250.160.0/20", "10.250.176.0/20"},
+			},
+		},
+		"Split CIDR into zone blocks with a single subnet": {
+			givenLayout:    SubnetLayout{ZoneBits: 3, SubnetBits: []int{0}},
+			givenCidr:      "10.250.0.0/22",
+			givenZoneCount: 2,
+			expectedSubnets: [][]string{
+				{"10.250.0.0/25"},
+				{"10.250.0.128/25"},
+			},
+		},
+		"Use the smallest zone blocks for the zone count": {
+			givenLayout:    SubnetLayout{SubnetBits: []int{0}},
+			givenCidr:      "10.250.0.0/22",
+			givenZoneCount: 3,
+			expectedSubnets: [][]string{
+				{"10.250.0.0/24"},
+				{"10.250.1.0/24"},
+				{"10.250.2.0/24"},
+			},
+		},
+		"Use the whole CIDR for a single zone": {
+			givenLayout:     SubnetLayout{SubnetBits: []int{0}},
+			givenCidr:       "10.250.0.0/22",
+			givenZoneCount:  1,
+			expectedSubnets: [][]string{{"10.250.0.0/22"}},
+		},
+		"Start with the network address of the CIDR": {
+			givenLayout:     SubnetLayout{ZoneBits: 1, SubnetBits: []int{0}},
+			givenCidr:       "10.250.1.17/22",
+			givenZoneCount:  1,
+			expectedSubnets: [][]string{{"10.250.0.0/23"}},
+		},
+		"Return error for invalid CIDR": {
+			givenLayout:    SubnetLayout{ZoneBits: 3, SubnetBits: []int{0}},
+			givenCidr:      "",
+			givenZoneCount: 1,
+			expectedErr:    ErrInvalidCIDR,
+		},
+		"Return error for IPv6 CIDR": {
+			givenLayout:    SubnetLayout{ZoneBits: 3, SubnetBits: []int{0}},
+			givenCidr:      "2001:db8::/64",
+			givenZoneCount: 1,
+			expectedErr:    ErrInvalidCIDR,
+		},
+		"Return error for too small CIDR": {
+			givenLayout:    SubnetLayout{ZoneBits: 2, SubnetBits: []int{1, 2, 2}},
+			givenCidr:      "10.250.0.0/29",
+			givenZoneCount: 1,
+			expectedErr:    ErrCIDRTooSmall,
+		},
+		"Return error for too many zones": {
+			givenLayout:    SubnetLayout{ZoneBits: 2, SubnetBits: []int{1, 2, 2}},
+			givenCidr:      "10.250.0.0/16",
+			givenZoneCount: 5,
+			expectedErr:    ErrTooManyZones,
+		},
+		"Return error for subnets not fitting into the zone block": {
+			givenLayout:    SubnetLayout{ZoneBits: 2, SubnetBits: []int{1, 1, 1}},
+			givenCidr:      "10.250.0.0/16",
+			givenZoneCount: 1,
+			expectedErr:    ErrInvalidSubnetLayout,
+		},
+		"Return error for subnets not aligned to their size": {
+			givenLayout:    SubnetLayout{ZoneBits: 2, SubnetBits: []int{2, 1}},
+			givenCidr:      "10.250.0.0/16",
+			givenZoneCount: 1,
+			expectedErr:    ErrInvalidSubnetLayout,
+		},
+	} {
+		t.Run(tname, func(t *testing.T) {
+			// when
+			zones, err := tcase.givenLayout.Plan(tcase.givenCidr, tcase.givenZoneCount)
+
+			// then
+			if tcase.expectedErr != nil {
+				require.ErrorIs(t, err, tcase.expectedErr)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Len(t, zones, len(tcase.expectedSubnets))
+			for i, expectedSubnets := range tcase.expectedSubnets {
+				var actualSubnets []string
+				for _, subnet := range zones[i] {
+					actualSubnets = append(actualSubnets, subnet.String())
+				}
+				assert.Equal(t, expectedSubnets, actualSubnets)
+			}
+		})
+	}
+}